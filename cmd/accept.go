@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fabienzucchet/adventofcode/internal/scaffolding"
+	"github.com/fabienzucchet/adventofcode/internal/solutions"
+	"github.com/fabienzucchet/adventofcode/internal/submission"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// acceptCmd represents the accept command
+var acceptCmd = &cobra.Command{
+	Use:   "accept",
+	Short: "Turn your solution's answer into a test",
+	Long: `Turn your solution's answer into a test.
+
+Examples:
+  # Run your solution to part 1 of day 10 and save its answer in tests.
+  adventofcode accept --day=10 --part=1
+
+  # Replace an answer saved previously.
+  adventofcode accept --day=10 --part=1 --force
+
+The CLI runs your solution, asks you to confirm its answer is right, then writes
+the answer to the output comment of ExamplePartOne or ExamplePartTwo in
+solution_test.go, and to the testdata/part-one-answer.txt or
+testdata/part-two-answer.txt file. If 'adventofcode submit' already recorded the
+answer as right, no confirmation is needed. When the input is read from
+standard input, confirm with the '--yes' flag instead.
+
+Once an answer is saved, the CLI refuses to replace it with a different one
+unless the '--force' flag is set. This protects your tests against regressions
+while you refactor your solution.`,
+	Args:    cobra.NoArgs,
+	PreRunE: bindFlags,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		workdir := viper.GetString("workdir")
+		key := solutions.Key{
+			Year:   viper.GetInt("year"),
+			Day:    viper.GetInt("day"),
+			Author: viper.GetString("author"),
+			Part:   viper.GetInt("part"),
+		}
+		if err := defaultAuthor(&key); err != nil {
+			return err
+		}
+
+		answer, _, err := solve(key, viper.GetString("input"), workdir)
+		if err != nil {
+			return err
+		}
+		answer = strings.TrimSpace(answer)
+
+		fmt.Printf("🧮 Answer to %s:\n%s\n", key, answer)
+
+		ledger, err := submission.LoadLedger(filepath.Join(workdir, key.Dir(), "testdata", "ledger.json"))
+		if err != nil {
+			return fmt.Errorf("loading ledger: %w", err)
+		}
+
+		correct, known := ledger.Correct(key.Part)
+		switch {
+		case known && correct == answer:
+			fmt.Println("⭐ adventofcode.com confirmed this answer.")
+		case known && !viper.GetBool("force"):
+			return fmt.Errorf("adventofcode.com confirmed a different answer: %q", correct)
+		case !viper.GetBool("yes") && viper.GetString("input") == "-":
+			// Standard input holds the puzzle input, not the user's reply.
+			return errors.New("cannot ask for confirmation when the input is read from standard input, use --yes")
+		case !viper.GetBool("yes") && !confirm("Is this the right answer?"):
+			fmt.Println("🙅 Answer not accepted.")
+			return nil
+		}
+
+		err = scaffolding.AcceptAnswer(filepath.Join(workdir, key.Dir()), key.Part, answer, viper.GetBool("force"))
+		if err != nil {
+			return fmt.Errorf("accepting answer: %w", err)
+		}
+
+		return nil
+	},
+}
+
+// confirm asks the user a yes-or-no question on standard input.
+func confirm(question string) bool {
+	fmt.Printf("❓ %s [y/N] ", question)
+
+	reply, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	reply = strings.ToLower(strings.TrimSpace(reply))
+	return reply == "y" || reply == "yes"
+}
+
+func init() {
+	rootCmd.AddCommand(acceptCmd)
+
+	acceptCmd.Flags().IntP("day", "d", 0, "The day to accept an answer for")
+	// Year defaults to latest Advent of Code.
+	acceptCmd.Flags().IntP("year", "y", latestYear(), "The year of Advent of Code to accept an answer for")
+	acceptCmd.Flags().IntP("part", "p", 1, "The part of the puzzle to accept an answer for (1 or 2)")
+	acceptCmd.Flags().StringP("author", "a", "", "Your name, username, or nickname (default is the puzzle's only author)")
+	acceptCmd.Flags().StringP("workdir", "w", "", "Your Advent of Code working directory")
+	acceptCmd.Flags().StringP("input", "i", "", "The input file to run the solution on, or - for standard input")
+	acceptCmd.Flags().BoolP("force", "f", false, "If true, overwrite a different answer")
+	acceptCmd.Flags().Bool("yes", false, "If true, accept the answer without confirmation")
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fabienzucchet/adventofcode/internal/bench"
+	"github.com/fabienzucchet/adventofcode/internal/solutions"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// goal is how long all solutions should take to run, in total.
+const goal = time.Second
+
+// benchCmd represents the bench command
+var benchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Benchmark solutions and detect regressions",
+	Long: `Benchmark solutions and detect regressions.
+
+Examples:
+  # Benchmark all solutions.
+  adventofcode bench
+
+  # Benchmark solutions for one year, or one day.
+  adventofcode bench --year=2022
+  adventofcode bench --year=2022 --day=16
+
+  # Make the current commit the baseline for future comparisons.
+  adventofcode bench --set-baseline
+
+Each solution runs on its input a few times before being measured, then as many
+times as possible within a second. The median and 95th percentile of its run
+time are reported, along with the median of its allocations.
+
+Results are saved in the benchmarks.json file of your working directory, keyed
+by git commit. They are compared to the results of the baseline commit, and any
+solution slower than its baseline by more than the threshold is reported as a
+regression.`,
+	Args:    cobra.NoArgs,
+	PreRunE: bindFlags,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		workdir := viper.GetString("workdir")
+		year, day, author := viper.GetInt("year"), viper.GetInt("day"), viper.GetString("author")
+
+		historyPath := viper.GetString("output")
+		if historyPath == "" {
+			historyPath = filepath.Join(workdir, "benchmarks.json")
+		}
+		history, err := bench.LoadHistory(historyPath)
+		if err != nil {
+			return fmt.Errorf("loading benchmark history: %w", err)
+		}
+		if baseline := viper.GetString("baseline"); baseline != "" {
+			history.Baseline = baseline
+		}
+
+		results := make(map[string]bench.Stats)
+		var total time.Duration
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintln(w, "SOLUTION\tMEDIAN\tP95\tALLOCS\tBYTES\tSAMPLES\t")
+
+		for _, key := range solutions.Keys() {
+			if (year != 0 && key.Year != year) || (day != 0 && key.Day != day) || (author != "" && key.Author != author) {
+				continue
+			}
+
+			input, err := ioutil.ReadFile(filepath.Join(workdir, key.InputFile()))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "  👉 Skipping %s: %v\n", key, err)
+				continue
+			}
+
+			s, err := solutions.Lookup(key)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(os.Stderr, "\r⏳ Benchmarking %s...", key)
+
+			var stats bench.Stats
+			withoutStdout(func() { stats, err = bench.Measure(s, input, bench.DefaultOptions) })
+			fmt.Fprint(os.Stderr, "\r\033[K")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "  ❌ %s failed: %v\n", key, err)
+				continue
+			}
+
+			results[key.String()] = stats
+			total += stats.Median
+
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%d\t\n", key, stats.Median, stats.P95, stats.Allocs, stats.Bytes, stats.Samples)
+		}
+		w.Flush()
+
+		fmt.Printf("\n⏱️  Total: %s", total.Round(time.Microsecond))
+		if total < goal {
+			fmt.Printf(" (under %s 🎉)\n", goal)
+		} else {
+			fmt.Printf(" (over %s 🐢)\n", goal)
+		}
+
+		regressions := history.Regressions(results, viper.GetFloat64("threshold"))
+
+		commit := gitCommit(workdir)
+		history.Record(commit, time.Now(), results)
+		if viper.GetBool("set-baseline") || history.Baseline == "" {
+			history.Baseline = commit
+		}
+
+		if err := history.Save(historyPath); err != nil {
+			return fmt.Errorf("saving benchmark history: %w", err)
+		}
+		fmt.Printf("💾 Saved results for commit %s (baseline: %s)\n", commit, history.Baseline)
+
+		if len(regressions) == 0 {
+			return nil
+		}
+
+		fmt.Printf("\n⚠️  Regressions compared to %s:\n", history.Baseline)
+		for _, r := range regressions {
+			fmt.Printf("  %s: %s → %s (+%.0f%%)\n", r.Name, r.Baseline.Median, r.Actual.Median, r.Slowdown()*100)
+		}
+
+		return fmt.Errorf("%d solutions are slower than their baseline", len(regressions))
+	},
+}
+
+// gitCommit returns the commit checked out in workdir, with a "-dirty" suffix
+// if tracked files were modified.
+func gitCommit(workdir string) string {
+	git := func(args ...string) (string, error) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = workdir
+		out, err := cmd.Output()
+		return strings.TrimSpace(string(out)), err
+	}
+
+	commit, err := git("rev-parse", "--short", "HEAD")
+	if err != nil {
+		return "unknown"
+	}
+
+	if status, err := git("status", "--porcelain", "--untracked-files=no"); err == nil && status != "" {
+		commit += "-dirty"
+	}
+
+	return commit
+}
+
+func init() {
+	rootCmd.AddCommand(benchCmd)
+
+	benchCmd.Flags().IntP("year", "y", 0, "Only benchmark solutions for this year")
+	benchCmd.Flags().IntP("day", "d", 0, "Only benchmark solutions for this day")
+	benchCmd.Flags().StringP("author", "a", "", "Only benchmark solutions by this author")
+	benchCmd.Flags().StringP("workdir", "w", "", "Your Advent of Code working directory")
+	benchCmd.Flags().StringP("output", "o", "", "File to save results to (default is benchmarks.json in your working directory)")
+	benchCmd.Flags().String("baseline", "", "Commit to compare results to (default is the baseline saved in the output file)")
+	benchCmd.Flags().Bool("set-baseline", false, "If true, make the current commit the baseline")
+	benchCmd.Flags().Float64("threshold", 0.2, "Slowdown above which a solution is reported as a regression (eg. 0.2 for 20%)")
+}
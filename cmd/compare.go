@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/fabienzucchet/adventofcode/internal/compare"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// compareCmd represents the compare command
+var compareCmd = &cobra.Command{
+	Use:   "compare",
+	Short: "Compare the solutions of all authors for a puzzle",
+	Long: `Compare the solutions of all authors for a puzzle.
+
+Examples:
+  # Compare all solutions to day 16.
+  adventofcode compare --year=2022 --day=16
+
+  # Skip benchmarks.
+  adventofcode compare --year=2022 --day=16 --bench=false
+
+Every author's solution runs on every author's input. Solutions that give
+different answers for the same input are flagged: one of them has a bug that
+only shows with some inputs.
+
+Then, each solution is benchmarked on its author's input.`,
+	Args:    cobra.NoArgs,
+	PreRunE: bindFlags,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		puzzle, err := compare.Load(viper.GetInt("year"), viper.GetInt("day"), viper.GetString("workdir"))
+		if err != nil {
+			return fmt.Errorf("loading puzzle: %w", err)
+		}
+
+		for _, part := range puzzle.Parts() {
+			var m *compare.Matrix
+			withoutStdout(func() { m = puzzle.Answers(part) })
+			printMatrix(m)
+		}
+
+		if !viper.GetBool("bench") {
+			return nil
+		}
+
+		fmt.Println("⏱️  Benchmarks, on each author's own input")
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintln(w, "AUTHOR\tPART\tTIME/OP\tNS/OP\tALLOCS/OP\tB/OP\t")
+		for _, part := range puzzle.Parts() {
+			var benchmarks []compare.Benchmark
+			withoutStdout(func() { benchmarks = puzzle.Benchmarks(part) })
+
+			for _, b := range benchmarks {
+				if b.Err != nil {
+					fmt.Fprintf(w, "%s\t%d\t❌\t\t\t\t\n", b.Author, b.Part)
+					continue
+				}
+				fmt.Fprintf(w, "%s\t%d\t%s\t%d\t%d\t%d\t\n",
+					b.Author, b.Part,
+					b.Stats.Median,
+					b.Stats.Median.Nanoseconds(), b.Stats.Allocs, b.Stats.Bytes,
+				)
+			}
+		}
+		w.Flush()
+
+		return nil
+	},
+}
+
+// printMatrix prints the answers of all solutions to all inputs in a table,
+// and flags disagreements between solutions.
+func printMatrix(m *compare.Matrix) {
+	fmt.Printf("🧮 Answers to part %d\n", m.Part)
+
+	disagreements := make(map[string]bool)
+	for _, input := range m.Disagreements() {
+		disagreements[input] = true
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprint(w, "SOLUTION \\ INPUT\t")
+	for _, input := range m.Inputs {
+		if disagreements[input] {
+			fmt.Fprintf(w, "%s ⚠️\t", input)
+		} else {
+			fmt.Fprintf(w, "%s\t", input)
+		}
+	}
+	fmt.Fprintln(w)
+
+	for _, solution := range m.Solutions {
+		fmt.Fprintf(w, "%s\t", solution)
+		for _, input := range m.Inputs {
+			a := m.Answers[solution][input]
+			switch {
+			case a.Err != nil:
+				fmt.Fprint(w, "❌ error\t")
+			case strings.Contains(a.Value, "\n"):
+				fmt.Fprint(w, "(multiline)\t")
+			default:
+				fmt.Fprintf(w, "%s\t", a.Value)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+	w.Flush()
+
+	for _, solution := range m.Solutions {
+		for _, input := range m.Inputs {
+			if err := m.Answers[solution][input].Err; err != nil {
+				fmt.Printf("  ❌ %s's solution failed on %s's input: %v\n", solution, input, err)
+			}
+		}
+	}
+	for _, input := range m.Disagreements() {
+		fmt.Printf("  ⚠️  Solutions disagree on %s's input.\n", input)
+	}
+
+	fmt.Println()
+}
+
+// withoutStdout calls f with standard output discarded, so that solutions
+// printing debug information do not clutter the results.
+func withoutStdout(f func()) {
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		f()
+		return
+	}
+	defer devNull.Close()
+
+	stdout := os.Stdout
+	os.Stdout = devNull
+	defer func() { os.Stdout = stdout }()
+
+	f()
+}
+
+func init() {
+	rootCmd.AddCommand(compareCmd)
+
+	compareCmd.Flags().IntP("day", "d", 0, "The day to compare solutions for")
+	// Year defaults to latest Advent of Code.
+	compareCmd.Flags().IntP("year", "y", latestYear(), "The year of Advent of Code to compare solutions for")
+	compareCmd.Flags().StringP("workdir", "w", "", "Your Advent of Code working directory")
+	compareCmd.Flags().Bool("bench", true, "If true, benchmark each solution")
+}
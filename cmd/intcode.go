@@ -0,0 +1,529 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/fabienzucchet/adventofcode/helpers"
+	"github.com/fabienzucchet/adventofcode/y2019/opcode"
+	"github.com/spf13/cobra"
+)
+
+// dataWidth is the number of values of data regions listed per line.
+const dataWidth = 8
+
+// intcodeCmd represents the intcode command
+var intcodeCmd = &cobra.Command{
+	Use:   "intcode",
+	Short: "Tools for the Intcode programs of Advent of Code 2019",
+	Long: `Tools for the Intcode programs of Advent of Code 2019.
+
+Intcode programs are the inputs of many puzzles of 2019. See the y2019/opcode
+package for the computer that runs them.`,
+}
+
+// intcodeDisasmCmd represents the intcode disasm command
+var intcodeDisasmCmd = &cobra.Command{
+	Use:   "disasm <file>",
+	Short: "Disassemble an Intcode program",
+	Long: `Disassemble an Intcode program.
+
+Examples:
+  # Disassemble the input of day 9.
+  adventofcode intcode disasm y2019/d09/fabienz/testdata/input.txt
+
+  # Read the program from standard input.
+  cat input.txt | adventofcode intcode disasm -
+
+Each instruction is listed with its address and parameters. Parameters are
+written #4 for immediate values, [100] for positions, and [rb+3] for positions
+relative to the relative base. Addresses that jumps target are marked with
+labels, eg. L0012.
+
+Instructions are found by following the program from address 0. Values that
+are never reached, or that do not decode as instructions, are listed as DATA.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		program, err := readProgram(args[0])
+		if err != nil {
+			return err
+		}
+
+		instructions := opcode.Disassemble(program)
+		labels := opcode.Labels(instructions)
+
+		for _, instruction := range instructions {
+			if instruction.JumpTarget {
+				fmt.Printf("%s:\n", opcode.Label(instruction.Address))
+			}
+
+			if instruction.Data == nil {
+				fmt.Printf("%04d: %s\n", instruction.Address, instruction.Format(labels))
+				continue
+			}
+
+			// Split data regions in lines of a few values.
+			for i := 0; i < len(instruction.Data); i += dataWidth {
+				end := i + dataWidth
+				if end > len(instruction.Data) {
+					end = len(instruction.Data)
+				}
+				line := opcode.Instruction{Address: instruction.Address + i, Data: instruction.Data[i:end]}
+				fmt.Printf("%04d: %s\n", line.Address, line)
+			}
+		}
+
+		return nil
+	},
+}
+
+// intcodeDebugCmd represents the intcode debug command
+var intcodeDebugCmd = &cobra.Command{
+	Use:   "debug <file>",
+	Short: "Debug an Intcode program interactively",
+	Long: `Debug an Intcode program interactively.
+
+Examples:
+  # Debug the input of day 9, with 1 as its first input.
+  adventofcode intcode debug y2019/d09/fabienz/testdata/input.txt --input=1
+
+The debugger reads commands from standard input. Type 'help' for a list of
+commands. An empty line repeats the last command.
+
+The last steps can be undone with 'back'. Steps reading inputs give them back
+when undone.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		program, err := readProgram(args[0])
+		if err != nil {
+			return err
+		}
+
+		inputs, err := cmd.Flags().GetIntSlice("input")
+		if err != nil {
+			return err
+		}
+
+		d := opcode.NewDebugger(&opcode.Intcode{Program: program, Inputs: inputs})
+		return debug(d, os.Stdin, os.Stdout)
+	},
+}
+
+// intcodeRunCmd represents the intcode run command
+var intcodeRunCmd = &cobra.Command{
+	Use:   "run <file>",
+	Short: "Run an Intcode program interactively",
+	Long: `Run an Intcode program interactively.
+
+Examples:
+  # Run the input of day 9, with 1 as its first input.
+  adventofcode intcode run y2019/d09/fabienz/testdata/input.txt --input=1
+
+  # Play the text adventure of day 25.
+  adventofcode intcode run --ascii y2019/d25/yournamehere/testdata/input.txt
+
+  # Run a springscript program with the springdroid of day 21.
+  adventofcode intcode run --ascii input.txt < springscript.txt
+
+  # Find where the BOOST program of day 9 spends its time.
+  adventofcode intcode run y2019/d09/fabienz/testdata/input.txt -i 2 --profile
+
+Outputs are printed one per line. When the program needs an input, it is read
+from standard input, as integers separated by commas.
+
+With --ascii, outputs are printed as text, and each line of standard input is
+given to the program as characters, followed by a newline. Outputs that are not
+ASCII characters, such as the answers to puzzles, are printed as integers.
+
+With --trace, each instruction the program runs is written to a file, with the
+values it read and wrote. With --profile, the number of times each instruction
+ran is printed to standard error once the program stops, even if it fails, to
+find the hot spots of the program.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		program, err := readProgram(args[0])
+		if err != nil {
+			return err
+		}
+
+		inputs, err := cmd.Flags().GetIntSlice("input")
+		if err != nil {
+			return err
+		}
+		ascii, err := cmd.Flags().GetBool("ascii")
+		if err != nil {
+			return err
+		}
+
+		intcode := &opcode.Intcode{Program: program, Inputs: inputs}
+
+		tracePath, err := cmd.Flags().GetString("trace")
+		if err != nil {
+			return err
+		}
+		var (
+			traces    []opcode.TraceFunc
+			traceFile *os.File
+			trace     *bufio.Writer
+		)
+		if tracePath != "" {
+			traceFile, err = os.Create(tracePath)
+			if err != nil {
+				return fmt.Errorf("creating trace file: %w", err)
+			}
+			// Only closes the file on early returns, it is closed below otherwise.
+			defer traceFile.Close()
+			trace = bufio.NewWriter(traceFile)
+			traces = append(traces, opcode.TraceTo(trace))
+		}
+
+		profile, err := cmd.Flags().GetBool("profile")
+		if err != nil {
+			return err
+		}
+		profiler := opcode.NewProfiler()
+		if profile {
+			traces = append(traces, profiler.Trace)
+		}
+
+		if len(traces) > 0 {
+			intcode.Trace = func(s opcode.Step) {
+				for _, trace := range traces {
+					trace(s)
+				}
+			}
+		}
+
+		// The report and the trace are written even if the program fails, as
+		// that is when they are the most useful.
+		err = interact(intcode, ascii, os.Stdin, os.Stdout)
+		if profile {
+			fmt.Fprintln(os.Stderr)
+			if reportErr := profiler.WriteReport(os.Stderr, program); err == nil {
+				err = reportErr
+			}
+		}
+		if trace != nil {
+			if flushErr := trace.Flush(); err == nil && flushErr != nil {
+				err = fmt.Errorf("writing trace file: %w", flushErr)
+			}
+			if closeErr := traceFile.Close(); err == nil && closeErr != nil {
+				err = fmt.Errorf("closing trace file: %w", closeErr)
+			}
+		}
+		return err
+	},
+}
+
+// interact runs intcode until it halts, reading its inputs from r and writing
+// its outputs to w, as text if ascii is true.
+func interact(intcode *opcode.Intcode, ascii bool, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	a := opcode.ASCII{Intcode: intcode}
+
+	for {
+		var (
+			results []int
+			err     error
+		)
+		if ascii {
+			var text string
+			text, results, err = a.Run()
+			fmt.Fprint(w, text)
+		} else {
+			results, err = intcode.RunIntcode()
+			intcode.Outputs = nil
+		}
+		for _, result := range results {
+			fmt.Fprintln(w, result)
+		}
+
+		if err != nil {
+			return fmt.Errorf("running program: %w", err)
+		}
+		if intcode.State == opcode.StateHalted {
+			return nil
+		}
+
+		// The program waits for an input.
+		if !ascii {
+			fmt.Fprint(w, "> ")
+		}
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return fmt.Errorf("reading input: %w", err)
+			}
+			return errors.New("the program needs an input, but there is none left")
+		}
+
+		if ascii {
+			a.WriteLine(scanner.Text())
+			continue
+		}
+		values, err := helpers.IntsFromString(strings.TrimSpace(scanner.Text()), ",")
+		if err != nil {
+			fmt.Fprintf(w, "❌ Invalid input: %v\n", err)
+			continue
+		}
+		intcode.Inputs = append(intcode.Inputs, values...)
+	}
+}
+
+const debugHelp = `Commands:
+  step, s [n]             Run n instructions (default 1)
+  continue, c             Run until a breakpoint, a watchpoint, or an input
+  back, rs [n]            Undo the last n instructions (default 1)
+  break, b <addr|op>      Break on an address, or on an opcode (eg. OUT)
+  watch, w <addr>         Break when the program writes to a memory cell
+  delete, d <addr|op>     Remove breakpoints and watchpoints
+  info, i                 List breakpoints and watchpoints
+  regs, r                 Print registers
+  mem, x <addr> [n]       Print n memory cells (default 1)
+  set <addr> <value>      Set a memory cell
+  input <value>...        Add inputs
+  outputs, o              Print outputs
+  list, l [n]             Disassemble n instructions (default 5)
+  help, h                 Print this help
+  quit, q                 Quit
+`
+
+// debug runs the debugger REPL, reading commands from r and writing to w until
+// r is exhausted or the user quits.
+func debug(d *opcode.Debugger, r io.Reader, w io.Writer) error {
+	intcode := d.Intcode
+	scanner := bufio.NewScanner(r)
+
+	// Prints where and why the program stopped.
+	printStop := func(stop opcode.Stop, err error) {
+		switch stop.Reason {
+		case opcode.StopWatchpoint:
+			fmt.Fprintf(w, "👀 [%d] changed from %d to %d\n", stop.Address, stop.Old, stop.New)
+		case opcode.StopBreakpoint:
+			fmt.Fprintln(w, "🛑 Breakpoint")
+		case opcode.StopInput:
+			fmt.Fprintln(w, "⌨️  Waiting for input; use 'input <value>...'")
+		case opcode.StopHalt:
+			fmt.Fprintln(w, "🏁 Halted")
+		case opcode.StopFault:
+			fmt.Fprintf(w, "❌ Faulted: %v\n", err)
+		}
+		fmt.Fprintln(w, currentInstruction(intcode, intcode.Pos))
+	}
+
+	// Parses an optional count argument.
+	count := func(args []string, n int) (int, error) {
+		if len(args) == 0 {
+			return n, nil
+		}
+		return strconv.Atoi(args[0])
+	}
+
+	fmt.Fprintln(w, currentInstruction(intcode, intcode.Pos))
+
+	var last []string
+	for {
+		fmt.Fprint(w, "(intcode) ")
+		if !scanner.Scan() {
+			fmt.Fprintln(w)
+			return scanner.Err()
+		}
+
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			fields = last
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		last = fields
+		command, args := fields[0], fields[1:]
+
+		var err error
+		switch command {
+		case "step", "s":
+			var n int
+			if n, err = count(args, 1); err == nil {
+				printStop(d.Step(n))
+			}
+		case "continue", "c":
+			printStop(d.Continue())
+		case "back", "rs":
+			var n int
+			if n, err = count(args, 1); err == nil {
+				if err = d.Back(n); err == nil {
+					fmt.Fprintln(w, currentInstruction(intcode, intcode.Pos))
+				}
+			}
+		case "break", "b", "watch", "w", "delete", "d":
+			if len(args) != 1 {
+				err = fmt.Errorf("usage: %s <addr|op>", command)
+				break
+			}
+			err = setBreakpoint(d, command, args[0])
+		case "info", "i":
+			addresses, ops, watched := d.Breakpoints()
+			fmt.Fprintf(w, "Breakpoints: %v\n", addresses)
+			fmt.Fprint(w, "Opcode breakpoints: [")
+			for i, op := range ops {
+				if i > 0 {
+					fmt.Fprint(w, " ")
+				}
+				fmt.Fprint(w, op.Mnemonic())
+			}
+			fmt.Fprintln(w, "]")
+			fmt.Fprintf(w, "Watchpoints: %v\n", watched)
+		case "regs", "r":
+			fmt.Fprintf(w, "Pos: %d\nRelativeBase: %d\nState: %s\nInputs: %v\n", intcode.Pos, intcode.RelativeBase, intcode.State, intcode.Inputs)
+		case "mem", "x":
+			if len(args) == 0 {
+				err = fmt.Errorf("usage: %s <addr> [n]", command)
+				break
+			}
+			var address, n int
+			if address, err = strconv.Atoi(args[0]); err != nil {
+				break
+			}
+			if n, err = count(args[1:], 1); err != nil {
+				break
+			}
+			for i := address; i < address+n; i++ {
+				var value int
+				if value, err = intcode.GetValue(i); err != nil {
+					break
+				}
+				fmt.Fprintf(w, "[%d] %d\n", i, value)
+			}
+		case "set":
+			if len(args) != 2 {
+				err = fmt.Errorf("usage: %s <addr> <value>", command)
+				break
+			}
+			var address, value int
+			if address, err = strconv.Atoi(args[0]); err != nil {
+				break
+			}
+			if value, err = strconv.Atoi(args[1]); err != nil {
+				break
+			}
+			err = d.Poke(address, value)
+		case "input":
+			for _, arg := range args {
+				var value int
+				if value, err = strconv.Atoi(arg); err != nil {
+					break
+				}
+				intcode.Inputs = append(intcode.Inputs, value)
+			}
+		case "outputs", "o":
+			fmt.Fprintln(w, intcode.Outputs)
+		case "list", "l":
+			var n int
+			if n, err = count(args, 5); err != nil {
+				break
+			}
+			for i, pos := 0, intcode.Pos; i < n; i++ {
+				fmt.Fprintln(w, currentInstruction(intcode, pos))
+				instruction, decodeErr := intcode.Instruction(pos)
+				if decodeErr != nil {
+					break
+				}
+				pos += instruction.Len()
+			}
+		case "help", "h":
+			fmt.Fprint(w, debugHelp)
+		case "quit", "q":
+			return nil
+		default:
+			err = fmt.Errorf("unknown command %q; type 'help' for a list of commands", command)
+		}
+
+		if err != nil {
+			fmt.Fprintf(w, "❌ %v\n", err)
+		}
+	}
+}
+
+// setBreakpoint sets or deletes a breakpoint or a watchpoint, on an address or
+// an opcode mnemonic.
+func setBreakpoint(d *opcode.Debugger, command, arg string) error {
+	address, err := strconv.Atoi(arg)
+	if err != nil {
+		op, ok := opcode.ParseMnemonic(arg)
+		if !ok {
+			return fmt.Errorf("invalid address or opcode %q", arg)
+		}
+		switch command {
+		case "break", "b":
+			d.BreakOn(op)
+		case "delete", "d":
+			d.ClearOn(op)
+		default:
+			return fmt.Errorf("cannot watch opcode %q", arg)
+		}
+		return nil
+	}
+
+	switch command {
+	case "break", "b":
+		d.Break(address)
+	case "watch", "w":
+		d.Watch(address)
+	default:
+		d.Clear(address)
+	}
+	return nil
+}
+
+// currentInstruction returns the instruction at pos in the memory of intcode,
+// with its address. Values that do not decode are shown as data.
+func currentInstruction(intcode *opcode.Intcode, pos int) string {
+	instruction, err := intcode.Instruction(pos)
+	if err != nil {
+		value, _ := intcode.GetValue(pos)
+		instruction = opcode.Instruction{Address: pos, Data: []int{value}}
+	}
+	return fmt.Sprintf("%04d: %s", pos, instruction)
+}
+
+// readProgram reads the Intcode program in the file at path, or in standard
+// input if path is "-".
+func readProgram(path string) ([]int, error) {
+	var (
+		content []byte
+		err     error
+	)
+	if path == "-" {
+		content, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		content, err = ioutil.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading program: %w", err)
+	}
+
+	program, err := helpers.IntsFromString(strings.TrimSpace(string(content)), ",")
+	if err != nil {
+		return nil, fmt.Errorf("parsing program: %w", err)
+	}
+
+	return program, nil
+}
+
+func init() {
+	rootCmd.AddCommand(intcodeCmd)
+	intcodeCmd.AddCommand(intcodeDisasmCmd)
+	intcodeCmd.AddCommand(intcodeDebugCmd)
+	intcodeCmd.AddCommand(intcodeRunCmd)
+
+	intcodeDebugCmd.Flags().IntSliceP("input", "i", nil, "Inputs to give to the program, separated by commas")
+	intcodeRunCmd.Flags().IntSliceP("input", "i", nil, "Inputs to give to the program, separated by commas")
+	intcodeRunCmd.Flags().Bool("ascii", false, "Read and write text, one character per value")
+	intcodeRunCmd.Flags().String("trace", "", "Write the instructions the program runs to this file")
+	intcodeRunCmd.Flags().Bool("profile", false, "Print the number of runs of each instruction once the program halts")
+}
@@ -3,7 +3,9 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"time"
 
+	"github.com/fabienzucchet/adventofcode/internal/aocclient"
 	"github.com/spf13/cobra"
 
 	homedir "github.com/mitchellh/go-homedir"
@@ -38,6 +40,11 @@ func init() {
 	// will be global for your application.
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.adventofcode.yaml)")
+
+	// Settings for requests to adventofcode.com, shared by all subcommands.
+	rootCmd.PersistentFlags().String("base-url", aocclient.DefaultBaseURL, "The address of the Advent of Code website")
+	rootCmd.PersistentFlags().String("user-agent", aocclient.DefaultUserAgent, "User-Agent sent to adventofcode.com; please add your contact information")
+	rootCmd.PersistentFlags().Duration("throttle", aocclient.DefaultInterval, "Minimum delay between two requests to adventofcode.com")
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -66,3 +73,34 @@ func initConfig() {
 		fmt.Fprintln(os.Stderr, "⚙️  Using config file:", viper.ConfigFileUsed())
 	}
 }
+
+// bindFlags binds the flags of cmd to viper keys. Subcommands share key names
+// (eg. "day" or "year"), so flags are bound just before the command runs
+// rather than when it is registered.
+func bindFlags(cmd *cobra.Command, args []string) error {
+	return viper.BindPFlags(cmd.Flags())
+}
+
+// newClient builds a client for adventofcode.com from the configuration.
+func newClient() (*aocclient.Client, error) {
+	client, err := aocclient.New(aocclient.Config{
+		BaseURL:   viper.GetString("base-url"),
+		Cookie:    viper.GetString("cookie"),
+		UserAgent: viper.GetString("user-agent"),
+		Interval:  viper.GetDuration("throttle"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("making client for adventofcode.com: %w", err)
+	}
+
+	return client, nil
+}
+
+// latestYear returns the year of the latest Advent of Code.
+func latestYear() int {
+	year, month, _ := time.Now().Date()
+	if month < time.December {
+		year--
+	}
+	return year
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fabienzucchet/adventofcode/internal/solutions"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// runCmd represents the run command
+var runCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Run a solution and print its answer",
+	Long: `Run a solution and print its answer.
+
+Examples:
+  # Run part 2 of day 10 on your input.
+  adventofcode run --year=2022 --day=10 --part=2 --author=fabienz
+
+  # Run part 1 of day 10 on another input.
+  adventofcode run --year=2022 --day=10 --part=1 --input=example.txt
+
+  # Read the input from standard input.
+  cat example.txt | adventofcode run --day=10 --part=1 --input=-
+
+The author defaults to the only author of solutions to the puzzle, if there is
+only one.
+
+By default, the solution runs on the input found in its package's testdata
+directory. Solutions are looked up in the registry of the CLI, which is updated
+when scaffolding a package. You can update it manually with:
+
+  go generate ./internal/solutions`,
+	Args:    cobra.NoArgs,
+	PreRunE: bindFlags,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key := solutions.Key{
+			Year:   viper.GetInt("year"),
+			Day:    viper.GetInt("day"),
+			Author: viper.GetString("author"),
+			Part:   viper.GetInt("part"),
+		}
+
+		if err := defaultAuthor(&key); err != nil {
+			return err
+		}
+
+		answer, elapsed, err := solve(key, viper.GetString("input"), viper.GetString("workdir"))
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(answer)
+		fmt.Fprintf(os.Stderr, "⏱️  Solved in %s\n", elapsed)
+
+		return nil
+	},
+}
+
+// defaultAuthor sets the author of key, if it has none, to the only author of
+// solutions to its puzzle.
+func defaultAuthor(key *solutions.Key) error {
+	if key.Author != "" {
+		return nil
+	}
+
+	authors := solutions.Authors(key.Year, key.Day)
+	if len(authors) != 1 {
+		return fmt.Errorf("no author given, and %d authors solved day %d of %d; set --author", len(authors), key.Day, key.Year)
+	}
+	key.Author = authors[0]
+	return nil
+}
+
+// solve runs the solution identified by key on the input file at path, and
+// returns the answer and how long the solution took to find it.
+func solve(key solutions.Key, path, workdir string) (string, time.Duration, error) {
+	solution, err := solutions.Lookup(key)
+	if err != nil {
+		return "", 0, fmt.Errorf("finding solution: %w", err)
+	}
+
+	input, err := readInput(path, workdir, key)
+	if err != nil {
+		return "", 0, fmt.Errorf("reading input: %w", err)
+	}
+
+	answer := &bytes.Buffer{}
+
+	start := time.Now()
+	err = solution.Solve(bytes.NewReader(input), answer)
+	elapsed := time.Since(start)
+	if err != nil {
+		return "", 0, fmt.Errorf("running %s: %w", key, err)
+	}
+
+	return answer.String(), elapsed, nil
+}
+
+// readInput reads the input file at path. If path is "-", the input is read
+// from standard input. If path is empty, the input is read from the testdata
+// directory of the solution's package.
+func readInput(path, workdir string, key solutions.Key) ([]byte, error) {
+	switch path {
+	case "-":
+		return ioutil.ReadAll(os.Stdin)
+	case "":
+		path = filepath.Join(workdir, key.InputFile())
+	}
+
+	return ioutil.ReadFile(path)
+}
+
+func init() {
+	rootCmd.AddCommand(runCmd)
+
+	runCmd.Flags().IntP("day", "d", 0, "The day to run the solution of")
+	// Year defaults to latest Advent of Code.
+	runCmd.Flags().IntP("year", "y", latestYear(), "The year of Advent of Code to run the solution of")
+	runCmd.Flags().IntP("part", "p", 1, "The part of the puzzle to run the solution of (1 or 2)")
+	runCmd.Flags().StringP("author", "a", "", "The author of the solution (default is the puzzle's only author)")
+	runCmd.Flags().StringP("workdir", "w", "", "Your Advent of Code working directory")
+	runCmd.Flags().StringP("input", "i", "", "The input file to run the solution on, or - for standard input")
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/fabienzucchet/adventofcode/internal/solutions"
+)
+
+func TestDefaultAuthor(t *testing.T) {
+	tests := map[string]struct {
+		key      solutions.Key
+		expected string
+		wantErr  bool
+	}{
+		"only author": {
+			key:      solutions.Key{Year: 2022, Day: 1, Part: 1},
+			expected: "fabienz",
+		},
+		"given author": {
+			key:      solutions.Key{Year: 2022, Day: 1, Author: "bob", Part: 1},
+			expected: "bob",
+		},
+		"no solution": {
+			key:     solutions.Key{Year: 2022, Day: 26, Part: 1},
+			wantErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			key := test.key
+			err := defaultAuthor(&key)
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got author %q", key.Author)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if key.Author != test.expected {
+				t.Errorf("expected author %q, got %q", test.expected, key.Author)
+			}
+		})
+	}
+}
@@ -2,7 +2,8 @@ package cmd
 
 import (
 	"fmt"
-	"time"
+	"os/exec"
+	"runtime"
 
 	"github.com/fabienzucchet/adventofcode/internal/scaffolding"
 	"github.com/spf13/cobra"
@@ -25,22 +26,44 @@ Examples:
   # Provide a session cookie to download your input of the day.
   adventofcode scaffold --day=1 --cookie=abcdef0123...
 
+  # Download the puzzle description again, once part two is unlocked.
+  adventofcode scaffold --day=1 --refresh
+
+  # Get ready before the puzzle unlocks, and open it in your browser as soon
+  # as it does.
+  adventofcode scaffold --day=1 --wait --open
+
 The CLI assumes you are working on the latest Advent of Code. You can always
 override this behavior with the '--year' flag.
 
 To download your input, provide the value of the 'session' cookie for the
 adventofcode.com website. You can do this with the '--cookie' flag, the
 ADVENTOFCODE_COOKIE environment variable, or by setting the 'cookie' field in
-your configuration file.`,
-	Args: cobra.NoArgs,
+your configuration file.
+
+The puzzle description is saved to a README.md file next to your code, so you
+can read it offline. Part two of the puzzle is only included once you have
+solved part one, which requires a session cookie.
+
+With the '--wait' flag, the CLI scaffolds your code right away, then waits for
+the puzzle to unlock at midnight in New York (UTC-5) before downloading it.`,
+	Args:    cobra.NoArgs,
+	PreRunE: bindFlags,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
 		gen, err := scaffolding.NewGenerator(
 			viper.GetInt("day"),
 			viper.GetInt("year"),
 			viper.GetString("author"),
 			viper.GetString("workdir"),
-			viper.GetString("cookie"),
+			client,
 			viper.GetBool("force"),
+			viper.GetBool("refresh"),
+			viper.GetBool("wait"),
 		)
 		if err != nil {
 			return fmt.Errorf("making code generator: %w", err)
@@ -50,6 +73,13 @@ your configuration file.`,
 			return fmt.Errorf("building scaffolding: %w", err)
 		}
 
+		if viper.GetBool("open") {
+			url := fmt.Sprintf("%s/%d/day/%d", client.BaseURL(), viper.GetInt("year"), viper.GetInt("day"))
+			if err := openInBrowser(url); err != nil {
+				return fmt.Errorf("opening puzzle description: %w", err)
+			}
+		}
+
 		fmt.Println("🎅🏻 Merry coding!")
 
 		return nil
@@ -62,16 +92,33 @@ func init() {
 	scaffoldCmd.Flags().IntP("day", "d", 0, "The day to build scaffolding for")
 
 	// Year defaults to latest Advent of Code.
-	year, month, _ := time.Now().Date()
-	if month < time.December {
-		year--
-	}
-	scaffoldCmd.Flags().IntP("year", "y", year, "The year of Advent of Code you are working on")
+	scaffoldCmd.Flags().IntP("year", "y", latestYear(), "The year of Advent of Code you are working on")
 
 	scaffoldCmd.Flags().StringP("author", "a", "", "Your name, username, or nickname (eg. arthurb)")
 	scaffoldCmd.Flags().StringP("workdir", "w", "", "Your Advent of Code working directory")
 	scaffoldCmd.Flags().StringP("cookie", "c", "", "Your session cookie for adventofcode.com")
 	scaffoldCmd.Flags().BoolP("force", "f", false, "If true, overwrite existing files")
+	scaffoldCmd.Flags().BoolP("refresh", "r", false, "If true, download the puzzle description again")
+	scaffoldCmd.Flags().Bool("wait", false, "If true, wait for the puzzle to unlock")
+	scaffoldCmd.Flags().Bool("open", false, "If true, open the puzzle in your browser")
+}
 
-	viper.BindPFlags(scaffoldCmd.Flags())
+// openInBrowser opens url with the system's default browser.
+func openInBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	// Reap the launcher once it exits, without waiting for the browser.
+	go func() { _ = cmd.Wait() }()
+	return nil
 }
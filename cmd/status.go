@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fabienzucchet/adventofcode/internal/status"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// statusCmd represents the status command
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show which puzzles are solved",
+	Long: `Show which puzzles are solved.
+
+Examples:
+  # Show a calendar of the stars earned each year.
+  adventofcode status
+
+  # Only count the solutions of one author.
+  adventofcode status --author=fabienz
+
+  # Update the progress table of README.md.
+  adventofcode status --update-readme
+
+A part of a puzzle counts as solved once its function was changed from the one
+scaffolded, and its example test expects an actual answer rather than the
+placeholder. Days with solutions that solve no part yet are shown in progress.
+
+The --format flag can be text, json, or markdown. The Markdown table replaces
+what is between the <!-- status:begin --> and <!-- status:end --> markers of
+README.md when using --update-readme.`,
+	Args:    cobra.NoArgs,
+	PreRunE: bindFlags,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		workdir := viper.GetString("workdir")
+
+		years, err := status.Years(workdir)
+		if err != nil {
+			return fmt.Errorf("finding years: %w", err)
+		}
+		if year := viper.GetInt("year"); year != 0 {
+			years = []int{year}
+		}
+
+		progress, err := status.Scan(workdir, years, viper.GetString("author"))
+		if err != nil {
+			return fmt.Errorf("scanning solutions: %w", err)
+		}
+
+		if viper.GetBool("update-readme") {
+			return updateReadme(filepath.Join(workdir, "README.md"), progress)
+		}
+
+		switch format := viper.GetString("format"); format {
+		case "text":
+			return status.WriteCalendar(os.Stdout, progress)
+		case "markdown":
+			return status.WriteMarkdown(os.Stdout, progress)
+		case "json":
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(progress)
+		default:
+			return fmt.Errorf("unknown format %q", format)
+		}
+	},
+}
+
+// updateReadme replaces the progress table of the README at path.
+func updateReadme(path string, progress []status.Year) error {
+	doc, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading README: %w", err)
+	}
+
+	var table strings.Builder
+	if err := status.WriteMarkdown(&table, progress); err != nil {
+		return err
+	}
+
+	doc, err = status.UpdateSection(doc, table.String())
+	if err != nil {
+		return fmt.Errorf("updating %q: %w", path, err)
+	}
+
+	if err := ioutil.WriteFile(path, doc, 0644); err != nil {
+		return fmt.Errorf("writing README: %w", err)
+	}
+
+	fmt.Printf("📝 Updated progress table in %s\n", path)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+
+	statusCmd.Flags().IntP("year", "y", 0, "Only show progress for this year")
+	statusCmd.Flags().StringP("author", "a", "", "Only count solutions by this author")
+	statusCmd.Flags().StringP("workdir", "w", "", "Your Advent of Code working directory")
+	statusCmd.Flags().StringP("format", "f", "text", "Output format: text, json, or markdown")
+	statusCmd.Flags().Bool("update-readme", false, "If true, update the progress table in the README.md of your working directory")
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/fabienzucchet/adventofcode/internal/solutions"
+	"github.com/fabienzucchet/adventofcode/internal/submission"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// submitCmd represents the submit command
+var submitCmd = &cobra.Command{
+	Use:   "submit",
+	Short: "Submit your answer to adventofcode.com",
+	Long: `Submit your answer to adventofcode.com.
+
+Examples:
+  # Run your solution to part 1 of day 10 and submit its answer.
+  adventofcode submit --day=10 --part=1
+
+  # Submit an answer you found some other way.
+  adventofcode submit --day=10 --part=1 --answer=14060
+
+Every answer submitted is recorded in the testdata/ledger.json file of your
+solution's package. The CLI refuses to submit an answer that is known to be
+wrong, or that is out of the bounds set by previous answers that were too high
+or too low. If adventofcode.com asks you to wait before submitting another
+answer, the CLI waits for you.
+
+Submitting requires the value of the 'session' cookie for the adventofcode.com
+website, like downloading your input does. See 'adventofcode scaffold --help'.`,
+	Args:    cobra.NoArgs,
+	PreRunE: bindFlags,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key := solutions.Key{
+			Year:   viper.GetInt("year"),
+			Day:    viper.GetInt("day"),
+			Author: viper.GetString("author"),
+			Part:   viper.GetInt("part"),
+		}
+		if err := defaultAuthor(&key); err != nil {
+			return err
+		}
+
+		answer, err := answerToSubmit(viper.GetString("answer"), func() (string, error) {
+			answer, _, err := solve(key, viper.GetString("input"), viper.GetString("workdir"))
+			return answer, err
+		})
+		if err != nil {
+			return err
+		}
+
+		aoc, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		client, err := submission.NewClient(aoc)
+		if err != nil {
+			return fmt.Errorf("making client: %w", err)
+		}
+
+		ledgerPath := filepath.Join(viper.GetString("workdir"), key.Dir(), "testdata", "ledger.json")
+		ledger, err := submission.LoadLedger(ledgerPath)
+		if err != nil {
+			return fmt.Errorf("loading ledger: %w", err)
+		}
+
+		fmt.Printf("📮 Submitting %q for %s\n", answer, key)
+
+		result, err := client.Submit(key.Year, key.Day, key.Part, answer, ledger)
+		if err != nil {
+			return fmt.Errorf("submitting answer: %w", err)
+		}
+
+		switch result.Verdict {
+		case submission.VerdictCorrect:
+			fmt.Println("⭐ That's the right answer!")
+		case submission.VerdictTooHigh, submission.VerdictTooLow:
+			fmt.Printf("❌ That's not the right answer; your answer is %s.\n", result.Verdict)
+		case submission.VerdictIncorrect:
+			fmt.Println("❌ That's not the right answer.")
+		default:
+			fmt.Printf("🤔 %s\n", result.Message)
+		}
+
+		return nil
+	},
+}
+
+// answerToSubmit returns answer, or the answer of solve if answer is empty,
+// without surrounding whitespace such as the trailing newline of a solution.
+func answerToSubmit(answer string, solve func() (string, error)) (string, error) {
+	if answer == "" {
+		var err error
+		if answer, err = solve(); err != nil {
+			return "", err
+		}
+	}
+
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return "", errors.New("the answer to submit is empty")
+	}
+	return answer, nil
+}
+
+func init() {
+	rootCmd.AddCommand(submitCmd)
+
+	submitCmd.Flags().IntP("day", "d", 0, "The day to submit an answer for")
+	// Year defaults to latest Advent of Code.
+	submitCmd.Flags().IntP("year", "y", latestYear(), "The year of Advent of Code to submit an answer for")
+	submitCmd.Flags().IntP("part", "p", 1, "The part of the puzzle to submit an answer for (1 or 2)")
+	submitCmd.Flags().StringP("author", "a", "", "Your name, username, or nickname (default is the puzzle's only author)")
+	submitCmd.Flags().StringP("workdir", "w", "", "Your Advent of Code working directory")
+	submitCmd.Flags().StringP("cookie", "c", "", "Your session cookie for adventofcode.com")
+	submitCmd.Flags().String("answer", "", "The answer to submit (default is to run your solution)")
+	submitCmd.Flags().StringP("input", "i", "", "The input file to run the solution on, or - for standard input")
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAnswerToSubmit(t *testing.T) {
+	tests := map[string]struct {
+		flag     string
+		solution string
+		err      error
+		expected string
+		wantErr  bool
+	}{
+		"flag": {
+			flag:     " 42 ",
+			solution: "13",
+			expected: "42",
+		},
+		"solution": {
+			solution: " 42\n",
+			expected: "42",
+		},
+		"blank solution": {
+			solution: "\n",
+			wantErr:  true,
+		},
+		"failed solution": {
+			err:     errors.New("no solution"),
+			wantErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			answer, err := answerToSubmit(test.flag, func() (string, error) {
+				return test.solution, test.err
+			})
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got answer %q", answer)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if answer != test.expected {
+				t.Errorf("expected answer %q, got %q", test.expected, answer)
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package helpers
+
+// A Stack is a last in, first out collection of values. The zero value is an
+// empty stack.
+type Stack[T any] struct {
+	values []T
+}
+
+// Push adds v on top of the stack.
+func (s *Stack[T]) Push(v T) {
+	s.values = append(s.values, v)
+}
+
+// Pop removes and returns the value on top of the stack. ok is false if the
+// stack is empty.
+func (s *Stack[T]) Pop() (v T, ok bool) {
+	if len(s.values) == 0 {
+		return v, false
+	}
+
+	last := len(s.values) - 1
+	v = s.values[last]
+	// Let the value be garbage collected.
+	var zero T
+	s.values[last] = zero
+	s.values = s.values[:last]
+	return v, true
+}
+
+// Peek returns the value on top of the stack, without removing it.
+func (s *Stack[T]) Peek() (v T, ok bool) {
+	if len(s.values) == 0 {
+		return v, false
+	}
+	return s.values[len(s.values)-1], true
+}
+
+// Len returns the number of values in the stack.
+func (s *Stack[T]) Len() int {
+	return len(s.values)
+}
+
+// A Deque is a double-ended queue, backed by a ring buffer that grows as
+// needed. The zero value is an empty deque.
+type Deque[T any] struct {
+	buf  []T
+	head int
+	n    int
+}
+
+// PushBack adds v at the back of the deque.
+func (d *Deque[T]) PushBack(v T) {
+	d.grow()
+	d.buf[(d.head+d.n)&d.mask()] = v
+	d.n++
+}
+
+// PushFront adds v at the front of the deque.
+func (d *Deque[T]) PushFront(v T) {
+	d.grow()
+	d.head = (d.head - 1) & d.mask()
+	d.buf[d.head] = v
+	d.n++
+}
+
+// PopFront removes and returns the value at the front of the deque. ok is
+// false if the deque is empty.
+func (d *Deque[T]) PopFront() (v T, ok bool) {
+	if d.n == 0 {
+		return v, false
+	}
+
+	var zero T
+	v, d.buf[d.head] = d.buf[d.head], zero
+	d.head = (d.head + 1) & d.mask()
+	d.n--
+	return v, true
+}
+
+// PopBack removes and returns the value at the back of the deque. ok is false
+// if the deque is empty.
+func (d *Deque[T]) PopBack() (v T, ok bool) {
+	if d.n == 0 {
+		return v, false
+	}
+
+	var zero T
+	i := (d.head + d.n - 1) & d.mask()
+	v, d.buf[i] = d.buf[i], zero
+	d.n--
+	return v, true
+}
+
+// Front returns the value at the front of the deque, without removing it.
+func (d *Deque[T]) Front() (v T, ok bool) {
+	if d.n == 0 {
+		return v, false
+	}
+	return d.buf[d.head], true
+}
+
+// Back returns the value at the back of the deque, without removing it.
+func (d *Deque[T]) Back() (v T, ok bool) {
+	if d.n == 0 {
+		return v, false
+	}
+	return d.buf[(d.head+d.n-1)&d.mask()], true
+}
+
+// At returns the i-th value from the front of the deque. It panics if i is out
+// of range.
+func (d *Deque[T]) At(i int) T {
+	if i < 0 || i >= d.n {
+		panic("deque index out of range")
+	}
+	return d.buf[(d.head+i)&d.mask()]
+}
+
+// Len returns the number of values in the deque.
+func (d *Deque[T]) Len() int {
+	return d.n
+}
+
+// mask returns the mask of the indexes of the ring buffer, whose size is a
+// power of 2.
+func (d *Deque[T]) mask() int {
+	return len(d.buf) - 1
+}
+
+// grow makes room for one more value.
+func (d *Deque[T]) grow() {
+	if d.n < len(d.buf) {
+		return
+	}
+
+	size := 2 * len(d.buf)
+	if size == 0 {
+		size = 8
+	}
+	buf := make([]T, size)
+	n := copy(buf, d.buf[d.head:])
+	copy(buf[n:], d.buf[:d.head])
+	d.buf, d.head = buf, 0
+}
+
+// A PriorityQueue is a binary heap of values, where the value popped first is
+// the least one. Values pushed get a handle, to change or remove them later.
+type PriorityQueue[T any] struct {
+	less    func(a, b T) bool
+	handles []*Handle[T]
+}
+
+// A Handle is a value of a priority queue.
+type Handle[T any] struct {
+	value T
+	// Index of the handle in the heap, or -1 once popped or removed.
+	index int
+}
+
+// Value returns the value of the handle.
+func (h *Handle[T]) Value() T {
+	return h.value
+}
+
+// NewPriorityQueue returns an empty priority queue, whose values are ordered
+// by less.
+func NewPriorityQueue[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{less: less}
+}
+
+// Push adds v to the queue.
+func (q *PriorityQueue[T]) Push(v T) *Handle[T] {
+	h := &Handle[T]{value: v, index: len(q.handles)}
+	q.handles = append(q.handles, h)
+	q.up(h.index)
+	return h
+}
+
+// Pop removes and returns the least value of the queue. ok is false if the
+// queue is empty.
+func (q *PriorityQueue[T]) Pop() (v T, ok bool) {
+	if len(q.handles) == 0 {
+		return v, false
+	}
+	h := q.handles[0]
+	q.Remove(h)
+	return h.value, true
+}
+
+// Peek returns the least value of the queue, without removing it.
+func (q *PriorityQueue[T]) Peek() (v T, ok bool) {
+	if len(q.handles) == 0 {
+		return v, false
+	}
+	return q.handles[0].value, true
+}
+
+// Update sets the value of h to v, and moves it to its new place in the queue,
+// for instance after decreasing its key. It panics if h was removed.
+func (q *PriorityQueue[T]) Update(h *Handle[T], v T) {
+	if h.index < 0 {
+		panic("update of a value removed from the priority queue")
+	}
+	h.value = v
+	if !q.up(h.index) {
+		q.down(h.index)
+	}
+}
+
+// Remove removes h from the queue. Removing it again does nothing.
+func (q *PriorityQueue[T]) Remove(h *Handle[T]) {
+	i := h.index
+	if i < 0 {
+		return
+	}
+
+	last := len(q.handles) - 1
+	q.swap(i, last)
+	q.handles[last] = nil
+	q.handles = q.handles[:last]
+	h.index = -1
+	if i < last && !q.up(i) {
+		q.down(i)
+	}
+}
+
+// Len returns the number of values in the queue.
+func (q *PriorityQueue[T]) Len() int {
+	return len(q.handles)
+}
+
+func (q *PriorityQueue[T]) swap(i, j int) {
+	q.handles[i], q.handles[j] = q.handles[j], q.handles[i]
+	q.handles[i].index, q.handles[j].index = i, j
+}
+
+// up moves the value at i towards the root while it is less than its parent,
+// and reports whether it moved.
+func (q *PriorityQueue[T]) up(i int) bool {
+	moved := false
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !q.less(q.handles[i].value, q.handles[parent].value) {
+			break
+		}
+		q.swap(i, parent)
+		i, moved = parent, true
+	}
+	return moved
+}
+
+// down moves the value at i towards the leaves while one of its children is
+// less than it.
+func (q *PriorityQueue[T]) down(i int) {
+	for {
+		least := i
+		if left := 2*i + 1; left < len(q.handles) && q.less(q.handles[left].value, q.handles[least].value) {
+			least = left
+		}
+		if right := 2*i + 2; right < len(q.handles) && q.less(q.handles[right].value, q.handles[least].value) {
+			least = right
+		}
+		if least == i {
+			return
+		}
+		q.swap(i, least)
+		i = least
+	}
+}
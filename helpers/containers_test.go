@@ -0,0 +1,215 @@
+package helpers_test
+
+import (
+	"container/heap"
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/fabienzucchet/adventofcode/helpers"
+)
+
+func ExampleStack() {
+	var s helpers.Stack[rune]
+	for _, r := range "([{" {
+		s.Push(r)
+	}
+
+	for s.Len() > 0 {
+		r, _ := s.Pop()
+		fmt.Print(string(r))
+	}
+	// Output: {[(
+}
+
+func ExampleDeque() {
+	var d helpers.Deque[int]
+	d.PushBack(2)
+	d.PushBack(3)
+	d.PushFront(1)
+
+	front, _ := d.PopFront()
+	back, _ := d.PopBack()
+	fmt.Println(front, back, d.Len())
+	// Output: 1 3 1
+}
+
+func ExamplePriorityQueue() {
+	type task struct {
+		name     string
+		priority int
+	}
+	q := helpers.NewPriorityQueue(func(a, b task) bool { return a.priority < b.priority })
+
+	q.Push(task{"write", 2})
+	review := q.Push(task{"review", 3})
+	q.Push(task{"test", 1})
+
+	// Review first.
+	q.Update(review, task{"review", 0})
+
+	for q.Len() > 0 {
+		t, _ := q.Pop()
+		fmt.Println(t.name)
+	}
+	// Output:
+	// review
+	// test
+	// write
+}
+
+func TestDeque(t *testing.T) {
+	// Compare the deque to a slice, through many random operations that wrap
+	// the ring buffer around and grow it.
+	var d helpers.Deque[int]
+	var expected []int
+	rng := rand.New(rand.NewSource(2022))
+
+	for i := 0; i < 10000; i++ {
+		switch rng.Intn(4) {
+		case 0:
+			d.PushBack(i)
+			expected = append(expected, i)
+		case 1:
+			d.PushFront(i)
+			expected = append([]int{i}, expected...)
+		case 2:
+			v, ok := d.PopFront()
+			if ok != (len(expected) > 0) || ok && v != expected[0] {
+				t.Fatalf("step %d: expected front of %v, got %d", i, expected, v)
+			}
+			if ok {
+				expected = expected[1:]
+			}
+		case 3:
+			v, ok := d.PopBack()
+			if ok != (len(expected) > 0) || ok && v != expected[len(expected)-1] {
+				t.Fatalf("step %d: expected back of %v, got %d", i, expected, v)
+			}
+			if ok {
+				expected = expected[:len(expected)-1]
+			}
+		}
+
+		if d.Len() != len(expected) {
+			t.Fatalf("step %d: expected length %d, got %d", i, len(expected), d.Len())
+		}
+	}
+
+	for i, v := range expected {
+		if d.At(i) != v {
+			t.Fatalf("expected %d at %d, got %d", v, i, d.At(i))
+		}
+	}
+}
+
+func TestPriorityQueue(t *testing.T) {
+	q := helpers.NewPriorityQueue(func(a, b int) bool { return a < b })
+	rng := rand.New(rand.NewSource(2022))
+
+	var handles []*helpers.Handle[int]
+	for i := 0; i < 1000; i++ {
+		handles = append(handles, q.Push(rng.Intn(1000)))
+	}
+
+	// Change, then remove, some of the values.
+	for _, h := range handles[:300] {
+		q.Update(h, rng.Intn(1000))
+	}
+	for _, h := range handles[300:400] {
+		q.Remove(h)
+	}
+	q.Remove(handles[300])
+
+	var expected []int
+	for _, h := range append(handles[:300:300], handles[400:]...) {
+		expected = append(expected, h.Value())
+	}
+	sort.Ints(expected)
+
+	var popped []int
+	for q.Len() > 0 {
+		v, _ := q.Pop()
+		popped = append(popped, v)
+	}
+	if fmt.Sprint(popped) != fmt.Sprint(expected) {
+		t.Errorf("expected values in order:\n%v\ngot:\n%v", expected, popped)
+	}
+	if _, ok := q.Pop(); ok {
+		t.Error("expected empty queue")
+	}
+}
+
+// intHeap is a heap of ints for container/heap.
+type intHeap []int
+
+func (h intHeap) Len() int            { return len(h) }
+func (h intHeap) Less(i, j int) bool  { return h[i] < h[j] }
+func (h intHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *intHeap) Push(x interface{}) { *h = append(*h, x.(int)) }
+func (h *intHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// BenchmarkPriorityQueue pushes and pops 10000 values, in a PriorityQueue and
+// with container/heap.
+func BenchmarkPriorityQueue(b *testing.B) {
+	rng := rand.New(rand.NewSource(2022))
+	values := rng.Perm(10000)
+
+	b.Run("PriorityQueue", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			q := helpers.NewPriorityQueue(func(a, b int) bool { return a < b })
+			for _, v := range values {
+				q.Push(v)
+			}
+			for q.Len() > 0 {
+				q.Pop()
+			}
+		}
+	})
+
+	b.Run("container/heap", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			h := &intHeap{}
+			for _, v := range values {
+				heap.Push(h, v)
+			}
+			for h.Len() > 0 {
+				heap.Pop(h)
+			}
+		}
+	})
+}
+
+// BenchmarkDeque pushes 10000 values to a queue, and pops them, in a Deque and
+// in a re-sliced slice.
+func BenchmarkDeque(b *testing.B) {
+	b.Run("Deque", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			var d helpers.Deque[int]
+			for i := 0; i < 10000; i++ {
+				d.PushBack(i)
+				if i%3 == 0 {
+					d.PopFront()
+				}
+			}
+		}
+	})
+
+	b.Run("slice", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			var queue []int
+			for i := 0; i < 10000; i++ {
+				queue = append(queue, i)
+				if i%3 == 0 {
+					queue = queue[1:]
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,258 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A Grid is a rectangular 2D grid of values, stored row by row in a single
+// slice. X grows to the right, and Y grows downwards, as in puzzle inputs.
+type Grid[T any] struct {
+	Width, Height int
+	cells         []T
+}
+
+// NewGrid returns a grid of width by height zero values.
+func NewGrid[T any](width, height int) *Grid[T] {
+	if width < 0 || height < 0 {
+		panic(fmt.Sprintf("invalid grid size %dx%d", width, height))
+	}
+	return &Grid[T]{Width: width, Height: height, cells: make([]T, width*height)}
+}
+
+// GridFromLines returns the grid of the characters of lines, converted by
+// parse. All lines must have the same length.
+func GridFromLines[T any](lines []string, parse func(rune) (T, error)) (*Grid[T], error) {
+	if len(lines) == 0 {
+		return NewGrid[T](0, 0), nil
+	}
+
+	width := len([]rune(lines[0]))
+	g := NewGrid[T](width, len(lines))
+	for y, line := range lines {
+		x := 0
+		for _, r := range line {
+			if x == width {
+				break
+			}
+			v, err := parse(r)
+			if err != nil {
+				return nil, fmt.Errorf("line %d, column %d: %w", y+1, x+1, err)
+			}
+			g.cells[y*width+x] = v
+			x++
+		}
+		if n := len([]rune(line)); n != width {
+			return nil, fmt.Errorf("line %d: expected %d columns, got %d", y+1, width, n)
+		}
+	}
+
+	return g, nil
+}
+
+// RuneGridFromLines returns the grid of the characters of lines.
+func RuneGridFromLines(lines []string) (*Grid[rune], error) {
+	return GridFromLines(lines, func(r rune) (rune, error) { return r, nil })
+}
+
+// InBounds reports whether c is in the grid.
+func (g *Grid[T]) InBounds(c Coord2D) bool {
+	return c.X >= 0 && c.X < g.Width && c.Y >= 0 && c.Y < g.Height
+}
+
+// Get returns the value at c, and whether c is in the grid.
+func (g *Grid[T]) Get(c Coord2D) (T, bool) {
+	if !g.InBounds(c) {
+		var zero T
+		return zero, false
+	}
+	return g.cells[c.Y*g.Width+c.X], true
+}
+
+// At returns the value at c, or the zero value if c is out of the grid.
+func (g *Grid[T]) At(c Coord2D) T {
+	v, _ := g.Get(c)
+	return v
+}
+
+// Set sets the value at c. It panics if c is out of the grid.
+func (g *Grid[T]) Set(c Coord2D, v T) {
+	g.cells[g.index(c)] = v
+}
+
+func (g *Grid[T]) index(c Coord2D) int {
+	if !g.InBounds(c) {
+		panic(fmt.Sprintf("coordinates %v out of grid of size %dx%d", c, g.Width, g.Height))
+	}
+	return c.Y*g.Width + c.X
+}
+
+// Neighbors4 returns the coordinates of the neighbors of c in the grid,
+// without diagonals.
+func (g *Grid[T]) Neighbors4(c Coord2D) []Coord2D {
+	return g.neighbors(c, []Coord2D{
+		{X: 0, Y: -1}, // N
+		{X: -1, Y: 0}, // W
+		{X: 1, Y: 0},  // E
+		{X: 0, Y: 1},  // S
+	})
+}
+
+// Neighbors8 returns the coordinates of the neighbors of c in the grid,
+// including diagonals.
+func (g *Grid[T]) Neighbors8(c Coord2D) []Coord2D {
+	return g.neighbors(c, []Coord2D{
+		{X: -1, Y: -1}, // NW
+		{X: 0, Y: -1},  // N
+		{X: 1, Y: -1},  // NE
+		{X: -1, Y: 0},  // W
+		{X: 1, Y: 0},   // E
+		{X: -1, Y: 1},  // SW
+		{X: 0, Y: 1},   // S
+		{X: 1, Y: 1},   // SE
+	})
+}
+
+func (g *Grid[T]) neighbors(c Coord2D, directions []Coord2D) []Coord2D {
+	neighbors := make([]Coord2D, 0, len(directions))
+	for _, d := range directions {
+		if n := c.Add(d); g.InBounds(n) {
+			neighbors = append(neighbors, n)
+		}
+	}
+	return neighbors
+}
+
+// Row returns the values of row y. The row shares the memory of the grid.
+func (g *Grid[T]) Row(y int) []T {
+	g.index(Coord2D{X: 0, Y: y})
+	return g.cells[y*g.Width : (y+1)*g.Width : (y+1)*g.Width]
+}
+
+// Column returns a copy of the values of column x.
+func (g *Grid[T]) Column(x int) []T {
+	return g.Line(Coord2D{X: x, Y: 0}, Coord2D{X: 0, Y: 1})
+}
+
+// Line returns a copy of the values from start, moving by step until leaving
+// the grid. For instance, a step of {1, 1} gives a diagonal.
+func (g *Grid[T]) Line(start, step Coord2D) []T {
+	if step == (Coord2D{}) {
+		panic("line with a null step")
+	}
+
+	var values []T
+	for c := start; g.InBounds(c); c = c.Add(step) {
+		values = append(values, g.cells[c.Y*g.Width+c.X])
+	}
+	return values
+}
+
+// Find returns the coordinates of the first value, row by row, for which match
+// is true.
+func (g *Grid[T]) Find(match func(T) bool) (Coord2D, bool) {
+	for i, v := range g.cells {
+		if match(v) {
+			return Coord2D{X: i % g.Width, Y: i / g.Width}, true
+		}
+	}
+	return Coord2D{}, false
+}
+
+// FindAll returns the coordinates of all the values, row by row, for which
+// match is true.
+func (g *Grid[T]) FindAll(match func(T) bool) []Coord2D {
+	var found []Coord2D
+	for i, v := range g.cells {
+		if match(v) {
+			found = append(found, Coord2D{X: i % g.Width, Y: i / g.Width})
+		}
+	}
+	return found
+}
+
+// Clone returns a copy of the grid.
+func (g *Grid[T]) Clone() *Grid[T] {
+	return &Grid[T]{Width: g.Width, Height: g.Height, cells: append([]T(nil), g.cells...)}
+}
+
+// Transpose returns a copy of the grid, mirrored along its diagonal.
+func (g *Grid[T]) Transpose() *Grid[T] {
+	return g.transform(g.Height, g.Width, func(c Coord2D) Coord2D {
+		return Coord2D{X: c.Y, Y: c.X}
+	})
+}
+
+// Rotate returns a copy of the grid, rotated clockwise by 90°.
+func (g *Grid[T]) Rotate() *Grid[T] {
+	return g.transform(g.Height, g.Width, func(c Coord2D) Coord2D {
+		return Coord2D{X: g.Height - 1 - c.Y, Y: c.X}
+	})
+}
+
+// FlipLeftRight returns a copy of the grid, mirrored left to right.
+func (g *Grid[T]) FlipLeftRight() *Grid[T] {
+	return g.transform(g.Width, g.Height, func(c Coord2D) Coord2D {
+		return Coord2D{X: g.Width - 1 - c.X, Y: c.Y}
+	})
+}
+
+// FlipUpDown returns a copy of the grid, mirrored upside down.
+func (g *Grid[T]) FlipUpDown() *Grid[T] {
+	return g.transform(g.Width, g.Height, func(c Coord2D) Coord2D {
+		return Coord2D{X: c.X, Y: g.Height - 1 - c.Y}
+	})
+}
+
+// transform returns a grid of the given size, with the value at c of g moved
+// to move(c).
+func (g *Grid[T]) transform(width, height int, move func(Coord2D) Coord2D) *Grid[T] {
+	t := NewGrid[T](width, height)
+	for i, v := range g.cells {
+		c := move(Coord2D{X: i % g.Width, Y: i / g.Width})
+		t.cells[c.Y*width+c.X] = v
+	}
+	return t
+}
+
+// Format returns the grid drawn with a character per value, one row per line.
+func (g *Grid[T]) Format(char func(T) rune) string {
+	var b strings.Builder
+	for i, v := range g.cells {
+		b.WriteRune(char(v))
+		if (i+1)%g.Width == 0 {
+			b.WriteRune('\n')
+		}
+	}
+	return b.String()
+}
+
+// String returns the grid, one row per line. Runes and bytes are written as
+// characters, booleans as '#' and '.', and other values with fmt, separated
+// by spaces.
+func (g *Grid[T]) String() string {
+	var b strings.Builder
+	for i, v := range g.cells {
+		switch v := any(v).(type) {
+		case rune:
+			b.WriteRune(v)
+		case byte:
+			b.WriteByte(v)
+		case bool:
+			if v {
+				b.WriteByte('#')
+			} else {
+				b.WriteByte('.')
+			}
+		default:
+			if i%g.Width != 0 {
+				b.WriteByte(' ')
+			}
+			fmt.Fprint(&b, v)
+		}
+		if (i+1)%g.Width == 0 {
+			b.WriteByte('\n')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,179 @@
+package helpers_test
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/fabienzucchet/adventofcode/helpers"
+)
+
+func ExampleGridFromLines() {
+	lines := []string{
+		"123",
+		"456",
+	}
+
+	grid, err := helpers.GridFromLines(lines, func(r rune) (int, error) {
+		return int(r - '0'), nil
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(grid.At(helpers.Coord2D{X: 2, Y: 1}))
+	fmt.Println(grid.Column(1))
+	fmt.Print(grid.Rotate())
+	// Output:
+	// 6
+	// [2 5]
+	// 4 1
+	// 5 2
+	// 6 3
+}
+
+func ExampleGrid_Neighbors4() {
+	grid, err := helpers.RuneGridFromLines([]string{
+		"#..",
+		"...",
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(grid.Neighbors4(helpers.Coord2D{X: 0, Y: 0}))
+	fmt.Println(len(grid.Neighbors8(helpers.Coord2D{X: 1, Y: 0})))
+	// Output:
+	// [{1 0} {0 1}]
+	// 5
+}
+
+func TestGridFromLines(t *testing.T) {
+	tests := map[string]struct {
+		lines []string
+		err   string
+	}{
+		"ragged": {
+			lines: []string{"..", "..."},
+			err:   "line 2: expected 2 columns, got 3",
+		},
+		"invalid": {
+			lines: []string{"..", ".x"},
+			err:   "line 2, column 2: invalid rune: x",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := helpers.GridFromLines(test.lines, func(r rune) (int, error) {
+				if r != '.' {
+					return 0, fmt.Errorf("invalid rune: %c", r)
+				}
+				return 0, nil
+			})
+			if err == nil || err.Error() != test.err {
+				t.Errorf("expected error %q, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestGridTransforms(t *testing.T) {
+	grid, err := helpers.RuneGridFromLines([]string{
+		"ab",
+		"cd",
+		"ef",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := map[string]struct {
+		grid     *helpers.Grid[rune]
+		expected string
+	}{
+		"transpose":  {grid: grid.Transpose(), expected: "ace\nbdf\n"},
+		"rotate":     {grid: grid.Rotate(), expected: "eca\nfdb\n"},
+		"left right": {grid: grid.FlipLeftRight(), expected: "ba\ndc\nfe\n"},
+		"up down":    {grid: grid.FlipUpDown(), expected: "ef\ncd\nab\n"},
+		"rotate x4":  {grid: grid.Rotate().Rotate().Rotate().Rotate(), expected: grid.String()},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if test.grid.String() != test.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", test.expected, test.grid)
+			}
+		})
+	}
+
+	if diagonal := string(grid.Line(helpers.Coord2D{X: 0, Y: 1}, helpers.Coord2D{X: 1, Y: 1})); diagonal != "cf" {
+		t.Errorf("expected diagonal %q, got %q", "cf", diagonal)
+	}
+	if c, ok := grid.Find(func(r rune) bool { return r == 'd' }); !ok || c != (helpers.Coord2D{X: 1, Y: 1}) {
+		t.Errorf("expected to find d at {1 1}, got %v", c)
+	}
+	if _, ok := grid.Get(helpers.Coord2D{X: 2, Y: 0}); ok {
+		t.Error("expected {2 0} to be out of the grid")
+	}
+}
+
+// benchmarkLines returns a square of digits, as in many puzzle inputs.
+func benchmarkLines(size int) []string {
+	lines := make([]string, size)
+	for y := range lines {
+		var b strings.Builder
+		for x := 0; x < size; x++ {
+			b.WriteByte(byte('0' + (x*7+y*13)%10))
+		}
+		lines[y] = b.String()
+	}
+	return lines
+}
+
+// BenchmarkGrid sums the neighbors of every cell of a grid, in a Grid and in
+// an IntGrid2D.
+func BenchmarkGrid(b *testing.B) {
+	lines := benchmarkLines(140)
+
+	b.Run("Grid", func(b *testing.B) {
+		grid, err := helpers.GridFromLines(lines, func(r rune) (int, error) { return int(r - '0'), nil })
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.ResetTimer()
+		for n := 0; n < b.N; n++ {
+			sum := 0
+			for y := 0; y < grid.Height; y++ {
+				for x := 0; x < grid.Width; x++ {
+					for _, c := range grid.Neighbors8(helpers.Coord2D{X: x, Y: y}) {
+						sum += grid.At(c)
+					}
+				}
+			}
+		}
+	})
+
+	b.Run("IntGrid2D", func(b *testing.B) {
+		grid := make(helpers.IntGrid2D)
+		for y, line := range lines {
+			for x, r := range line {
+				grid[helpers.Coord2D{X: x, Y: y}] = int(r - '0')
+			}
+		}
+
+		b.ResetTimer()
+		for n := 0; n < b.N; n++ {
+			sum := 0
+			for y := 0; y < len(lines); y++ {
+				for x := 0; x < len(lines[y]); x++ {
+					for _, c := range (helpers.Coord2D{X: x, Y: y}).Neighbors() {
+						sum += grid[c]
+					}
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,194 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Letters of the fonts of Advent of Code, by height. Glyphs are drawn with '#'
+// for lit pixels, and are compared without the blank columns around them.
+var fonts = map[int]map[string]rune{
+	6: font(6, map[rune]string{
+		'A': ".##.|#..#|#..#|####|#..#|#..#",
+		'B': "###.|#..#|###.|#..#|#..#|###.",
+		'C': ".##.|#..#|#...|#...|#..#|.##.",
+		'E': "####|#...|###.|#...|#...|####",
+		'F': "####|#...|###.|#...|#...|#...",
+		'G': ".##.|#..#|#...|#.##|#..#|.###",
+		'H': "#..#|#..#|####|#..#|#..#|#..#",
+		'I': "###|.#.|.#.|.#.|.#.|###",
+		'J': "..##|...#|...#|...#|#..#|.##.",
+		'K': "#..#|#.#.|##..|#.#.|#.#.|#..#",
+		'L': "#...|#...|#...|#...|#...|####",
+		'O': ".##.|#..#|#..#|#..#|#..#|.##.",
+		'P': "###.|#..#|#..#|###.|#...|#...",
+		'R': "###.|#..#|#..#|###.|#.#.|#..#",
+		'S': ".###|#...|#...|.##.|...#|###.",
+		'U': "#..#|#..#|#..#|#..#|#..#|.##.",
+		'Y': "#...#|#...#|.#.#.|..#..|..#..|..#..",
+		'Z': "####|...#|..#.|.#..|#...|####",
+	}),
+	10: font(10, map[rune]string{
+		'A': "..##..|.#..#.|#....#|#....#|#....#|######|#....#|#....#|#....#|#....#",
+		'B': "#####.|#....#|#....#|#....#|#####.|#....#|#....#|#....#|#....#|#####.",
+		'C': ".####.|#....#|#.....|#.....|#.....|#.....|#.....|#.....|#....#|.####.",
+		'E': "######|#.....|#.....|#.....|#####.|#.....|#.....|#.....|#.....|######",
+		'F': "######|#.....|#.....|#.....|#####.|#.....|#.....|#.....|#.....|#.....",
+		'G': ".####.|#....#|#.....|#.....|#.....|#..###|#....#|#....#|#...##|.###.#",
+		'H': "#....#|#....#|#....#|#....#|######|#....#|#....#|#....#|#....#|#....#",
+		'J': "...###|....#.|....#.|....#.|....#.|....#.|....#.|#...#.|#...#.|.###..",
+		'K': "#....#|#...#.|#..#..|#.#...|##....|##....|#.#...|#..#..|#...#.|#....#",
+		'L': "#.....|#.....|#.....|#.....|#.....|#.....|#.....|#.....|#.....|######",
+		'N': "#....#|##...#|##...#|#.#..#|#.#..#|#..#.#|#..#.#|#...##|#...##|#....#",
+		'P': "#####.|#....#|#....#|#....#|#####.|#.....|#.....|#.....|#.....|#.....",
+		'R': "#####.|#....#|#....#|#....#|#####.|#..#..|#...#.|#...#.|#....#|#....#",
+		'X': "#....#|#....#|.#..#.|.#..#.|..##..|..##..|.#..#.|.#..#.|#....#|#....#",
+		'Z': "######|.....#|.....#|....#.|...#..|..#...|.#....|#.....|#.....|######",
+	}),
+}
+
+// font returns the letters of glyphs, drawn row by row separated by '|', by
+// their key.
+func font(height int, glyphs map[rune]string) map[string]rune {
+	letters := make(map[string]rune, len(glyphs))
+	for letter, glyph := range glyphs {
+		rows := strings.Split(glyph, "|")
+		g := NewGrid[bool](len(rows[0]), height)
+		for y, row := range rows {
+			for x, r := range row {
+				g.Set(Coord2D{X: x, Y: y}, r == '#')
+			}
+		}
+		letters[glyphKey(g, 0, g.Width)] = letter
+	}
+	return letters
+}
+
+// OCR reads the letters drawn in grid, where true values are lit pixels. The
+// letters must use one of the fonts of Advent of Code, 6 or 10 pixels high,
+// and be separated by blank columns. Unrecognized letters are read as '?', and
+// reported in the error with their drawing.
+func OCR(grid *Grid[bool]) (string, error) {
+	lit := grid.FindAll(func(lit bool) bool { return lit })
+	if len(lit) == 0 {
+		return "", fmt.Errorf("no letters to read")
+	}
+
+	// Bounds of the lit pixels.
+	top, bottom := lit[0].Y, lit[len(lit)-1].Y
+	left, right := lit[0].X, lit[0].X
+	for _, c := range lit {
+		if c.X < left {
+			left = c.X
+		}
+		if c.X > right {
+			right = c.X
+		}
+	}
+
+	height := bottom - top + 1
+	letters, ok := fonts[height]
+	if !ok {
+		return "", fmt.Errorf("no font of letters %d pixels high", height)
+	}
+
+	text := grid.crop(Coord2D{X: left, Y: top}, right-left+1, height)
+	var (
+		b            strings.Builder
+		n            int
+		unrecognized []string
+	)
+	for x := 0; x < text.Width; {
+		if blankColumn(text, x) {
+			x++
+			continue
+		}
+
+		end := x
+		for end < text.Width && !blankColumn(text, end) {
+			end++
+		}
+		n++
+		key := glyphKey(text, x, end)
+		if letter, ok := letters[key]; ok {
+			b.WriteRune(letter)
+		} else {
+			b.WriteRune('?')
+			unrecognized = append(unrecognized, fmt.Sprintf("letter %d, at column %d:\n%s", n, left+x+1, key))
+		}
+		x = end
+	}
+
+	if len(unrecognized) > 0 {
+		return b.String(), fmt.Errorf("unrecognized letters in %q:\n%s", b.String(), strings.Join(unrecognized, "\n"))
+	}
+	return b.String(), nil
+}
+
+// OCRString reads the letters drawn in s, one row of pixels per line, where
+// spaces and dots are dark pixels, and any other character a lit pixel. See
+// OCR.
+func OCRString(s string) (string, error) {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+
+	width := 0
+	for _, line := range lines {
+		if n := len([]rune(line)); n > width {
+			width = n
+		}
+	}
+
+	grid := NewGrid[bool](width, len(lines))
+	for y, line := range lines {
+		for x, r := range []rune(line) {
+			grid.Set(Coord2D{X: x, Y: y}, r != ' ' && r != '.')
+		}
+	}
+
+	return OCR(grid)
+}
+
+// crop returns a copy of the width by height part of g, starting at origin.
+func (g *Grid[T]) crop(origin Coord2D, width, height int) *Grid[T] {
+	c := NewGrid[T](width, height)
+	for y := 0; y < height; y++ {
+		copy(c.Row(y), g.Row(origin.Y + y)[origin.X:origin.X+width])
+	}
+	return c
+}
+
+// blankColumn reports whether column x of g has no lit pixel.
+func blankColumn(g *Grid[bool], x int) bool {
+	for y := 0; y < g.Height; y++ {
+		if g.cells[y*g.Width+x] {
+			return false
+		}
+	}
+	return true
+}
+
+// glyphKey returns the drawing of the columns from start to end, excluded, of
+// g, without its blank columns on both sides.
+func glyphKey(g *Grid[bool], start, end int) string {
+	for start < end && blankColumn(g, start) {
+		start++
+	}
+	for end > start && blankColumn(g, end-1) {
+		end--
+	}
+
+	var b strings.Builder
+	for y := 0; y < g.Height; y++ {
+		if y > 0 {
+			b.WriteByte('\n')
+		}
+		for x := start; x < end; x++ {
+			if g.At(Coord2D{X: x, Y: y}) {
+				b.WriteByte('#')
+			} else {
+				b.WriteByte('.')
+			}
+		}
+	}
+	return b.String()
+}
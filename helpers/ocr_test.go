@@ -0,0 +1,135 @@
+package helpers_test
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/fabienzucchet/adventofcode/helpers"
+)
+
+func ExampleOCRString() {
+	screen := `
+#..#.####.#....#.....##..
+#..#.#....#....#....#..#.
+####.###..#....#....#..#.
+#..#.#....#....#....#..#.
+#..#.#....#....#....#..#.
+#..#.####.####.####..##..
+`
+
+	letters, err := helpers.OCRString(screen)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(letters)
+	// Output: HELLO
+}
+
+func ExampleOCR() {
+	grid, err := helpers.GridFromLines([]string{
+		"0110011110",
+		"1001010000",
+		"1000011100",
+		"1011010000",
+		"1001010000",
+		"0111011110",
+	}, func(r rune) (bool, error) { return r == '1', nil })
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	letters, err := helpers.OCR(grid)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(letters)
+	// Output: GE
+}
+
+func TestOCRString(t *testing.T) {
+	tests := map[string]struct {
+		screen string
+		want   string
+		err    string
+	}{
+		"large font": {
+			screen: strings.Join([]string{
+				"   #....#..######..#####.",
+				"   #....#.......#..#....#",
+				"   .#..#........#..#....#",
+				"   .#..#.......#...#....#",
+				"   ..##.......#....#####.",
+				"   ..##......#.....#..#..",
+				"   .#..#....#......#...#.",
+				"   .#..#...#.......#...#.",
+				"   #....#..#.......#....#",
+				"   #....#..######..#....#",
+			}, "\n"),
+			want: "XZR",
+		},
+		"blank margins": {
+			screen: "\n\n  ###  \n   #   \n   #   \n   #   \n   #   \n  ###  \n\n",
+			want:   "I",
+		},
+		"narrow and wide letters": {
+			screen: strings.Join([]string{
+				"###.#...#",
+				".#..#...#",
+				".#...#.#.",
+				".#....#..",
+				".#....#..",
+				"###...#..",
+			}, "\n"),
+			want: "IY",
+		},
+		"unrecognized letter": {
+			screen: strings.Join([]string{
+				"#..#.#...#",
+				"#..#.##.##",
+				"####.#.#.#",
+				"#..#.#...#",
+				"#..#.#...#",
+				"#..#.#...#",
+			}, "\n"),
+			want: "H?",
+			err: strings.Join([]string{
+				`unrecognized letters in "H?":`,
+				"letter 2, at column 6:",
+				"#...#",
+				"##.##",
+				"#.#.#",
+				"#...#",
+				"#...#",
+				"#...#",
+			}, "\n"),
+		},
+		"unknown height": {
+			screen: "#\n#\n#",
+			err:    "no font of letters 3 pixels high",
+		},
+		"blank": {
+			screen: "....\n....",
+			err:    "no letters to read",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := helpers.OCRString(test.screen)
+			if test.err != "" {
+				if err == nil || err.Error() != test.err {
+					t.Fatalf("got error %v, want %q", err, test.err)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
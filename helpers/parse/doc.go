@@ -0,0 +1,5 @@
+// Package parse reads the inputs of Advent of Code puzzles: integers in
+// arbitrary text, paragraphs, fields, and lines matching a pattern.
+//
+// Errors tell the line and the column where parsing failed, counted from 1.
+package parse
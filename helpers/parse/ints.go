@@ -0,0 +1,58 @@
+package parse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Ints returns all the integers in s, ignoring any other text. A minus or plus
+// sign belongs to the integer that follows it, unless it follows a digit, as in
+// ranges like "2-4".
+func Ints(s string) []int {
+	var ints []int
+	for i := 0; i < len(s); {
+		start := i
+		if (s[i] == '-' || s[i] == '+') && (i == 0 || !isDigit(s[i-1])) {
+			i++
+		}
+		end := i
+		for end < len(s) && isDigit(s[end]) {
+			end++
+		}
+		if end == i {
+			i = start + 1
+			continue
+		}
+
+		n, err := strconv.Atoi(s[start:end])
+		if err == nil {
+			ints = append(ints, n)
+		}
+		i = end
+	}
+	return ints
+}
+
+// IntList returns the integers in s, separated by sep. Unlike Ints, it fails
+// on values that are not integers.
+func IntList(s, sep string) ([]int, error) {
+	words := strings.Split(s, sep)
+	ints := make([]int, len(words))
+
+	column := 1
+	for i, w := range words {
+		n, err := strconv.Atoi(w)
+		if err != nil {
+			return nil, &Error{Column: column, Err: fmt.Errorf("%q is not an integer", w)}
+		}
+		ints[i] = n
+		column += len(w) + len(sep)
+	}
+
+	return ints, nil
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
@@ -0,0 +1,283 @@
+package parse_test
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fabienzucchet/adventofcode/helpers/parse"
+)
+
+func ExampleInts() {
+	fmt.Println(parse.Ints("Sensor at x=2, y=-18: closest beacon is at x=-2, y=15"))
+	fmt.Println(parse.Ints("2-4,6-8"))
+	// Output:
+	// [2 -18 -2 15]
+	// [2 4 6 8]
+}
+
+func ExampleParagraphs() {
+	input := "1000\n2000\n\n4000\n\n\n5000\n6000\n"
+
+	paragraphs, err := parse.Paragraphs(strings.NewReader(input))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(len(paragraphs), paragraphs)
+	// Output:
+	// 3 [[1000 2000] [4000] [5000 6000]]
+}
+
+func ExampleFields() {
+	fmt.Printf("%q\n", parse.Fields("Valve AA has flow rate=0; tunnels lead to valves DD, II", " =;,"))
+	// Output:
+	// ["Valve" "AA" "has" "flow" "rate" "0" "tunnels" "lead" "to" "valves" "DD" "II"]
+}
+
+func ExampleScanLines() {
+	type move struct {
+		Count    int
+		From, To int
+	}
+
+	lines := []string{
+		"move 1 from 2 to 1",
+		"move 3 from 1 to 3",
+	}
+
+	moves, err := parse.ScanLines[move](lines, "move {count} from {from} to {to}")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("%+v\n", moves)
+
+	_, err = parse.ScanLines[move]([]string{"move 1 from 2 to 1", "move 1 from 2 ot 1"}, "move {count} from {from} to {to}")
+	fmt.Println(err)
+	// Output:
+	// [{Count:1 From:2 To:1} {Count:3 From:1 To:3}]
+	// line 2, column 14: expected " to ", got " ot 1"
+}
+
+func ExamplePattern_Scan() {
+	var valve struct {
+		Name  string `parse:"valve"`
+		Rate  int
+		Leads string `parse:"valves"`
+	}
+
+	p := parse.MustCompile("Valve {valve} has flow rate={rate}; tunnels lead to valves {valves}")
+	if err := p.Scan("Valve AA has flow rate=13; tunnels lead to valves DD, II", &valve); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(valve.Name, valve.Rate, parse.Fields(valve.Leads, ", "))
+	// Output:
+	// AA 13 [DD II]
+}
+
+func TestInts(t *testing.T) {
+	tests := map[string][]int{
+		"":                nil,
+		"no numbers":      nil,
+		"x=-3":            {-3},
+		"+4 and -5":       {4, -5},
+		"2-4,6-8":         {2, 4, 6, 8},
+		"a-b-1":           {-1},
+		"--2":             {-2},
+		"123456789012345": {123456789012345},
+	}
+
+	for s, want := range tests {
+		if got := parse.Ints(s); !reflect.DeepEqual(got, want) {
+			t.Errorf("Ints(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func TestIntList(t *testing.T) {
+	ints, err := parse.IntList("3,-1,4", ",")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{3, -1, 4}; !reflect.DeepEqual(ints, want) {
+		t.Errorf("got %v, want %v", ints, want)
+	}
+
+	_, err = parse.IntList("3, 1", ", ")
+	if err != nil {
+		t.Error(err)
+	}
+
+	_, err = parse.IntList("10,2x,4", ",")
+	var perr *parse.Error
+	if !errors.As(err, &perr) || perr.Column != 4 {
+		t.Fatalf("got error %v, want an error at column 4", err)
+	}
+	if want := `column 4: "2x" is not an integer`; err.Error() != want {
+		t.Errorf("got error %q, want %q", err, want)
+	}
+}
+
+func TestParagraphs(t *testing.T) {
+	tests := map[string][][]string{
+		"":                 nil,
+		"\n\n":             nil,
+		"a":                {{"a"}},
+		"a\nb\n\nc":        {{"a", "b"}, {"c"}},
+		"\n\na\n  \nb\n\n": {{"a"}, {"b"}},
+	}
+
+	for input, want := range tests {
+		got, err := parse.Paragraphs(strings.NewReader(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Paragraphs(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestFields(t *testing.T) {
+	got := parse.Fields(" a, b;;c ", ",; ")
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+type sensor struct {
+	X, Y     int
+	Distance uint8 `parse:"d"`
+	Ratio    float64
+	Name     string
+	Active   bool
+	Links    []int
+}
+
+func TestScan(t *testing.T) {
+	tests := map[string]struct {
+		pattern string
+		s       string
+		want    sensor
+		err     string
+	}{
+		"ints": {
+			pattern: "at x={x}, y={y}",
+			s:       "at x=-2, y=15",
+			want:    sensor{X: -2, Y: 15},
+		},
+		"all kinds": {
+			pattern: "{name}: {d} {ratio} {active} [{links}]",
+			s:       "S1: 200 -0.5 true [1,-2, 3]",
+			want:    sensor{Name: "S1", Distance: 200, Ratio: -0.5, Active: true, Links: []int{1, -2, 3}},
+		},
+		"string at the end": {
+			pattern: "name={name}",
+			s:       "name=a b c",
+			want:    sensor{Name: "a b c"},
+		},
+		"braces": {
+			pattern: "{{{x}}}",
+			s:       "{4}",
+			want:    sensor{X: 4},
+		},
+		"missing literal": {
+			pattern: "at x={x}, y={y}",
+			s:       "at x=2 y=15",
+			err:     `column 7: expected ", y=", got " y=15"`,
+		},
+		"not an integer": {
+			pattern: "at x={x}",
+			s:       "at x=a",
+			err:     "column 6: {x}: expected a int",
+		},
+		"overflow": {
+			pattern: "{d}",
+			s:       "300",
+			err:     `column 1: {d}: invalid uint8 "300"`,
+		},
+		"trailing text": {
+			pattern: "x={x}",
+			s:       "x=1, y=2",
+			err:     `column 4: unexpected ", y=2"`,
+		},
+		"string without its end": {
+			pattern: "{name}!",
+			s:       "hello",
+			err:     `column 1: expected "!" after {name}`,
+		},
+		"unknown field": {
+			pattern: "{z}",
+			s:       "1",
+			err:     "no field of parse_test.sensor stores {z}",
+		},
+		"invalid pattern": {
+			pattern: "{x}{y}",
+			err:     `column 4: captures must be separated by text in pattern "{x}{y}"`,
+		},
+		"unclosed capture": {
+			pattern: "x={x",
+			err:     `column 3: unclosed capture in pattern "x={x"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got sensor
+			err := parse.Scan(test.s, test.pattern, &got)
+			if test.err != "" {
+				if err == nil || err.Error() != test.err {
+					t.Fatalf("got error %v, want %q", err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestScanDestination(t *testing.T) {
+	var x int
+	if err := parse.Scan("1", "{x}", &x); err == nil {
+		t.Error("expected an error when scanning into an int")
+	}
+
+	var s struct{ x int }
+	if err := parse.Scan("1", "{x}", &s); err == nil || !strings.Contains(err.Error(), "not exported") {
+		t.Errorf("got error %v, want an error about the unexported field", err)
+	}
+}
+
+func BenchmarkScanLines(b *testing.B) {
+	lines := make([]string, 1000)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("Sensor at x=%d, y=%d: closest beacon is at x=%d, y=%d", i, -i, 2*i, 3*i)
+	}
+	type report struct {
+		SX, SY, BX, BY int
+	}
+
+	b.Run("ScanLines", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = parse.ScanLines[report](lines, "Sensor at x={sx}, y={sy}: closest beacon is at x={bx}, y={by}")
+		}
+	})
+
+	b.Run("Ints", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, line := range lines {
+				_ = parse.Ints(line)
+			}
+		}
+	})
+}
@@ -0,0 +1,263 @@
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// A Pattern matches text like "Sensor at x={x}, y={y}", and stores the values
+// of its captures, like {x} and {y}, into the fields of a struct.
+//
+// A capture is stored in the exported field with a `parse:"name"` tag, or else
+// in the field with the same name, in any case. Integer, float and boolean
+// fields capture a value of their type. Strings capture text up to the next
+// literal text of the pattern, and slices of ints capture the integers in that
+// text, as Ints does. Write {{ and }} for literal braces.
+type Pattern struct {
+	source string
+	tokens []token
+}
+
+// A token of a pattern is a literal text or a capture.
+type token struct {
+	literal string
+	capture string
+}
+
+// Compile parses a pattern.
+func Compile(pattern string) (*Pattern, error) {
+	p := &Pattern{source: pattern}
+
+	var literal strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "{{"), strings.HasPrefix(pattern[i:], "}}"):
+			literal.WriteByte(pattern[i])
+			i++
+		case pattern[i] == '{':
+			end := strings.IndexByte(pattern[i:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("column %d: unclosed capture in pattern %q", i+1, pattern)
+			}
+			name := strings.TrimSpace(pattern[i+1 : i+end])
+			if name == "" {
+				return nil, fmt.Errorf("column %d: capture without a name in pattern %q", i+1, pattern)
+			}
+
+			if literal.Len() > 0 {
+				p.tokens = append(p.tokens, token{literal: literal.String()})
+				literal.Reset()
+			} else if len(p.tokens) > 0 {
+				return nil, fmt.Errorf("column %d: captures must be separated by text in pattern %q", i+1, pattern)
+			}
+			p.tokens = append(p.tokens, token{capture: name})
+			i += end
+		case pattern[i] == '}':
+			return nil, fmt.Errorf("column %d: unexpected } in pattern %q", i+1, pattern)
+		default:
+			literal.WriteByte(pattern[i])
+		}
+	}
+	if literal.Len() > 0 {
+		p.tokens = append(p.tokens, token{literal: literal.String()})
+	}
+
+	return p, nil
+}
+
+// MustCompile is like Compile, but panics if the pattern is invalid. It eases
+// the declaration of patterns in global variables.
+func MustCompile(pattern string) *Pattern {
+	p, err := Compile(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// String returns the source of the pattern.
+func (p *Pattern) String() string {
+	return p.source
+}
+
+// Scan matches s with the pattern, and stores its captures into the struct dst
+// points to.
+func (p *Pattern) Scan(s string, dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("expected a pointer to a struct, got %T", dst)
+	}
+	v = v.Elem()
+
+	pos := 0
+	for i, t := range p.tokens {
+		if t.capture == "" {
+			if !strings.HasPrefix(s[pos:], t.literal) {
+				return &Error{Column: pos + 1, Err: fmt.Errorf("expected %q, got %q", t.literal, excerpt(s[pos:]))}
+			}
+			pos += len(t.literal)
+			continue
+		}
+
+		field, err := fieldOf(v, t.capture)
+		if err != nil {
+			return err
+		}
+
+		// Text of the capture, up to the next literal, or to the end.
+		next := ""
+		if i+1 < len(p.tokens) {
+			next = p.tokens[i+1].literal
+		}
+		end := len(s)
+		switch field.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			end = pos + numberLength(s[pos:], field.Kind())
+		default:
+			if next != "" {
+				j := strings.Index(s[pos:], next)
+				if j < 0 {
+					return &Error{Column: pos + 1, Err: fmt.Errorf("expected %q after {%s}", next, t.capture)}
+				}
+				end = pos + j
+			}
+		}
+
+		if err := set(field, s[pos:end]); err != nil {
+			return &Error{Column: pos + 1, Err: fmt.Errorf("{%s}: %w", t.capture, err)}
+		}
+		pos = end
+	}
+
+	if pos < len(s) {
+		return &Error{Column: pos + 1, Err: fmt.Errorf("unexpected %q", excerpt(s[pos:]))}
+	}
+	return nil
+}
+
+// Scan matches s with pattern, and stores its captures into the struct dst
+// points to. See Pattern.
+func Scan(s, pattern string, dst interface{}) error {
+	p, err := Compile(pattern)
+	if err != nil {
+		return err
+	}
+	return p.Scan(s, dst)
+}
+
+// ScanLines matches each line with pattern, and returns the structs holding
+// their captures. See Pattern.
+func ScanLines[T any](lines []string, pattern string) ([]T, error) {
+	p, err := Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]T, len(lines))
+	for i, line := range lines {
+		if err := p.Scan(line, &values[i]); err != nil {
+			return nil, atLine(err, i+1)
+		}
+	}
+	return values, nil
+}
+
+// fieldOf returns the field of the struct v that stores the capture name.
+func fieldOf(v reflect.Value, name string) (reflect.Value, error) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if tag, ok := t.Field(i).Tag.Lookup("parse"); ok && tag == name {
+			return fieldAt(v, i, name)
+		}
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if strings.EqualFold(t.Field(i).Name, name) {
+			return fieldAt(v, i, name)
+		}
+	}
+	return reflect.Value{}, fmt.Errorf("no field of %s stores {%s}", t, name)
+}
+
+func fieldAt(v reflect.Value, i int, name string) (reflect.Value, error) {
+	if !v.Type().Field(i).IsExported() {
+		return reflect.Value{}, fmt.Errorf("field %s of %s, which stores {%s}, is not exported", v.Type().Field(i).Name, v.Type(), name)
+	}
+	return v.Field(i), nil
+}
+
+// numberLength returns the length of the number at the start of s.
+func numberLength(s string, kind reflect.Kind) int {
+	n := 0
+	if n < len(s) && (s[n] == '-' || s[n] == '+') && kind != reflect.Uint {
+		n++
+	}
+	for n < len(s) && isDigit(s[n]) {
+		n++
+	}
+	if (kind == reflect.Float32 || kind == reflect.Float64) && n < len(s) && s[n] == '.' {
+		n++
+		for n < len(s) && isDigit(s[n]) {
+			n++
+		}
+	}
+	return n
+}
+
+// set stores the text of a capture in field.
+func set(field reflect.Value, text string) error {
+	var err error
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(text)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		if n, err = strconv.ParseInt(text, 10, field.Type().Bits()); err == nil {
+			field.SetInt(n)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var n uint64
+		if n, err = strconv.ParseUint(text, 10, field.Type().Bits()); err == nil {
+			field.SetUint(n)
+		}
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		if f, err = strconv.ParseFloat(text, field.Type().Bits()); err == nil {
+			field.SetFloat(f)
+		}
+	case reflect.Bool:
+		var b bool
+		if b, err = strconv.ParseBool(text); err == nil {
+			field.SetBool(b)
+		}
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.Int {
+			return fmt.Errorf("unsupported field type %s", field.Type())
+		}
+		field.Set(reflect.ValueOf(Ints(text)))
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+
+	var numErr *strconv.NumError
+	if errors.As(err, &numErr) {
+		if text == "" {
+			return fmt.Errorf("expected a %s", field.Kind())
+		}
+		return fmt.Errorf("invalid %s %q", field.Kind(), text)
+	}
+	return err
+}
+
+// excerpt returns the start of s, to show in errors.
+func excerpt(s string) string {
+	const length = 20
+	if len(s) > length {
+		return s[:length] + "…"
+	}
+	return s
+}
@@ -0,0 +1,78 @@
+package parse
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// An Error is an error at a position of the input.
+type Error struct {
+	// Line and column of the error, counted from 1, or 0 if unknown.
+	Line, Column int
+	Err          error
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.Line > 0 && e.Column > 0:
+		return fmt.Sprintf("line %d, column %d: %v", e.Line, e.Column, e.Err)
+	case e.Line > 0:
+		return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+	case e.Column > 0:
+		return fmt.Sprintf("column %d: %v", e.Column, e.Err)
+	default:
+		return e.Err.Error()
+	}
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// atLine returns err, at line if it does not have a line yet.
+func atLine(err error, line int) error {
+	if e, ok := err.(*Error); ok && e.Line == 0 {
+		return &Error{Line: line, Column: e.Column, Err: e.Err}
+	}
+	return &Error{Line: line, Err: err}
+}
+
+// Paragraphs returns the paragraphs of r: its groups of lines separated by
+// blank lines.
+func Paragraphs(r io.Reader) ([][]string, error) {
+	var (
+		paragraphs [][]string
+		paragraph  []string
+	)
+
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line := s.Text()
+		if strings.TrimSpace(line) != "" {
+			paragraph = append(paragraph, line)
+			continue
+		}
+		if paragraph != nil {
+			paragraphs = append(paragraphs, paragraph)
+			paragraph = nil
+		}
+	}
+	if s.Err() != nil {
+		return nil, fmt.Errorf("failed to scan reader: %w", s.Err())
+	}
+	if paragraph != nil {
+		paragraphs = append(paragraphs, paragraph)
+	}
+
+	return paragraphs, nil
+}
+
+// Fields splits s around any of the characters of seps, and drops empty
+// fields. For instance, Fields("a, b;c", ",; ") returns ["a", "b", "c"].
+func Fields(s, seps string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return strings.ContainsRune(seps, r)
+	})
+}
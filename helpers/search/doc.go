@@ -0,0 +1,3 @@
+// Package search finds shortest paths in graphs, such as mazes or the states
+// of a puzzle, described by a function returning the neighbors of a state.
+package search
@@ -0,0 +1,64 @@
+package search
+
+// Infinity is the distance between states that are not connected.
+const Infinity = int(^uint(0) >> 2)
+
+// Distances holds the shortest distances between all pairs of states of a
+// graph.
+type Distances[S comparable] struct {
+	index map[S]int
+	dist  [][]int
+}
+
+// FloydWarshall computes the shortest distances between all pairs of states,
+// with the Floyd–Warshall algorithm. It takes a time cubic in the number of
+// states, so it suits small dense graphs, such as the interesting places of a
+// bigger graph. Edges to states that are not in states are ignored.
+func FloydWarshall[S comparable](states []S, neighbors func(S) []Edge[S]) Distances[S] {
+	d := Distances[S]{index: make(map[S]int, len(states)), dist: make([][]int, len(states))}
+	for i, s := range states {
+		d.index[s] = i
+	}
+
+	for i, s := range states {
+		d.dist[i] = make([]int, len(states))
+		for j := range d.dist[i] {
+			d.dist[i][j] = Infinity
+		}
+		d.dist[i][i] = 0
+		for _, e := range neighbors(s) {
+			if j, ok := d.index[e.To]; ok && e.Cost < d.dist[i][j] {
+				d.dist[i][j] = e.Cost
+			}
+		}
+	}
+
+	for k := range states {
+		for i := range states {
+			if d.dist[i][k] == Infinity {
+				continue
+			}
+			for j := range states {
+				if through := d.dist[i][k] + d.dist[k][j]; through < d.dist[i][j] {
+					d.dist[i][j] = through
+				}
+			}
+		}
+	}
+
+	return d
+}
+
+// Distance returns the shortest distance from a state to another, and whether
+// there is a path between them.
+func (d Distances[S]) Distance(from, to S) (int, bool) {
+	i, ok := d.index[from]
+	if !ok {
+		return Infinity, false
+	}
+	j, ok := d.index[to]
+	if !ok {
+		return Infinity, false
+	}
+	return d.dist[i][j], d.dist[i][j] != Infinity
+}
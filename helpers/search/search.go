@@ -0,0 +1,145 @@
+package search
+
+import "github.com/fabienzucchet/adventofcode/helpers"
+
+// An Edge leads to a state, at a cost.
+type Edge[S comparable] struct {
+	To   S
+	Cost int
+}
+
+// A Result holds the states a search reached, with the cost of the shortest
+// path to each of them.
+type Result[S comparable] struct {
+	// Cost of the shortest path to each state reached. Searches that stop at a
+	// goal may not know the shortest path to states other than the goal.
+	Costs map[S]int
+	// Goal reached, if Found is true.
+	Goal  S
+	Found bool
+
+	start   S
+	parents map[S]S
+}
+
+// Cost returns the cost of the shortest path to the goal.
+func (r Result[S]) Cost() int {
+	return r.Costs[r.Goal]
+}
+
+// Path returns the states from the start of the search to to, both included,
+// or nil if the search did not reach to.
+func (r Result[S]) Path(to S) []S {
+	if _, ok := r.Costs[to]; !ok {
+		return nil
+	}
+
+	path := []S{to}
+	for to != r.start {
+		to = r.parents[to]
+		path = append(path, to)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+func newResult[S comparable](start S) Result[S] {
+	return Result[S]{
+		Costs:   map[S]int{start: 0},
+		start:   start,
+		parents: make(map[S]S),
+	}
+}
+
+// BFS searches the states reachable from start, in a graph whose edges all
+// cost 1, until it finds a state for which goal is true. With a nil goal, it
+// visits all the reachable states.
+func BFS[S comparable](start S, next func(S) []S, goal func(S) bool) Result[S] {
+	r := newResult(start)
+
+	var queue helpers.Deque[S]
+	queue.PushBack(start)
+	for queue.Len() > 0 {
+		s, _ := queue.PopFront()
+		if goal != nil && goal(s) {
+			r.Goal, r.Found = s, true
+			return r
+		}
+
+		for _, n := range next(s) {
+			if _, ok := r.Costs[n]; ok {
+				continue
+			}
+			r.Costs[n] = r.Costs[s] + 1
+			r.parents[n] = s
+			queue.PushBack(n)
+		}
+	}
+
+	return r
+}
+
+// Dijkstra searches the states reachable from start, in a graph whose edges
+// have non-negative costs, until it finds a state for which goal is true. With
+// a nil goal, it visits all the reachable states.
+func Dijkstra[S comparable](start S, neighbors func(S) []Edge[S], goal func(S) bool) Result[S] {
+	return AStar(start, neighbors, goal, nil)
+}
+
+// AStar searches the states reachable from start, like Dijkstra, visiting
+// first the states that heuristic estimates to be the closest to the goal. To
+// find shortest paths, heuristic must never overestimate the cost to reach the
+// goal, and must not drop by more than the cost of an edge along it, as the
+// Manhattan distance in a grid. A nil heuristic makes AStar behave like
+// Dijkstra.
+func AStar[S comparable](start S, neighbors func(S) []Edge[S], goal func(S) bool, heuristic func(S) int) Result[S] {
+	r := newResult(start)
+	estimate := func(s S, cost int) int {
+		if heuristic == nil {
+			return cost
+		}
+		return cost + heuristic(s)
+	}
+
+	// States to visit, with their handle in the queue until they are visited.
+	q := helpers.NewPriorityQueue(func(a, b item[S]) bool { return a.priority < b.priority })
+	pending := map[S]*helpers.Handle[item[S]]{start: q.Push(item[S]{state: start, priority: estimate(start, 0)})}
+	for q.Len() > 0 {
+		next, _ := q.Pop()
+		s := next.state
+		delete(pending, s)
+
+		if goal != nil && goal(s) {
+			r.Goal, r.Found = s, true
+			return r
+		}
+
+		for _, e := range neighbors(s) {
+			cost := r.Costs[s] + e.Cost
+			if known, ok := r.Costs[e.To]; ok && known <= cost {
+				continue
+			}
+			r.Costs[e.To] = cost
+			r.parents[e.To] = s
+
+			// Pending states move up the queue. Visited states are visited
+			// again, which only happens with inconsistent heuristics.
+			next := item[S]{state: e.To, priority: estimate(e.To, cost)}
+			if h, ok := pending[e.To]; ok {
+				q.Update(h, next)
+			} else {
+				pending[e.To] = q.Push(next)
+			}
+		}
+	}
+
+	return r
+}
+
+// A state to visit, by increasing priority.
+type item[S comparable] struct {
+	state    S
+	priority int
+}
@@ -0,0 +1,197 @@
+package search_test
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/fabienzucchet/adventofcode/helpers"
+	"github.com/fabienzucchet/adventofcode/helpers/search"
+)
+
+// maze is a grid where # are walls, and digits are the cost to enter a cell.
+var maze = []string{
+	"S911E",
+	"1###1",
+	"11111",
+}
+
+func parseMaze(t testing.TB, lines []string) (grid *helpers.Grid[rune], start, end helpers.Coord2D) {
+	grid, err := helpers.RuneGridFromLines(lines)
+	if err != nil {
+		t.Fatalf("could not parse maze: %v", err)
+	}
+	start, _ = grid.Find(func(r rune) bool { return r == 'S' })
+	end, _ = grid.Find(func(r rune) bool { return r == 'E' })
+	return grid, start, end
+}
+
+// steps returns the cells next to c that are not walls.
+func steps(grid *helpers.Grid[rune]) func(helpers.Coord2D) []helpers.Coord2D {
+	return func(c helpers.Coord2D) []helpers.Coord2D {
+		var next []helpers.Coord2D
+		for _, n := range grid.Neighbors4(c) {
+			if grid.At(n) != '#' {
+				next = append(next, n)
+			}
+		}
+		return next
+	}
+}
+
+// edges returns the cells next to c that are not walls, with the cost to
+// enter them.
+func edges(grid *helpers.Grid[rune]) func(helpers.Coord2D) []search.Edge[helpers.Coord2D] {
+	return func(c helpers.Coord2D) []search.Edge[helpers.Coord2D] {
+		var edges []search.Edge[helpers.Coord2D]
+		for _, n := range steps(grid)(c) {
+			cost := 1
+			if r := grid.At(n); r >= '0' && r <= '9' {
+				cost = int(r - '0')
+			}
+			edges = append(edges, search.Edge[helpers.Coord2D]{To: n, Cost: cost})
+		}
+		return edges
+	}
+}
+
+func ExampleBFS() {
+	// Count the steps from 0 to 100, adding 1 or doubling.
+	r := search.BFS(0, func(n int) []int {
+		return []int{n + 1, 2 * n}
+	}, func(n int) bool {
+		return n == 100
+	})
+
+	fmt.Println(r.Cost())
+	fmt.Println(r.Path(100))
+	// Output:
+	// 9
+	// [0 1 2 3 6 12 24 25 50 100]
+}
+
+func ExampleFloydWarshall() {
+	roads := map[string][]search.Edge[string]{
+		"Paris":  {{To: "Lyon", Cost: 465}, {To: "Nantes", Cost: 385}},
+		"Lyon":   {{To: "Paris", Cost: 465}, {To: "Nice", Cost: 470}},
+		"Nantes": {{To: "Paris", Cost: 385}},
+		"Nice":   {{To: "Lyon", Cost: 470}},
+	}
+
+	d := search.FloydWarshall([]string{"Paris", "Lyon", "Nantes", "Nice"}, func(city string) []search.Edge[string] {
+		return roads[city]
+	})
+
+	distance, ok := d.Distance("Nantes", "Nice")
+	if !ok {
+		log.Fatal("no road from Nantes to Nice")
+	}
+	fmt.Println(distance)
+	// Output: 1320
+}
+
+func TestSearches(t *testing.T) {
+	grid, start, end := parseMaze(t, maze)
+	manhattan := func(c helpers.Coord2D) int { return c.ManhattanDistance(end) }
+	isEnd := func(c helpers.Coord2D) bool { return c == end }
+
+	tests := map[string]struct {
+		result search.Result[helpers.Coord2D]
+		cost   int
+	}{
+		"BFS":      {result: search.BFS(start, steps(grid), isEnd), cost: 4},
+		"Dijkstra": {result: search.Dijkstra(start, edges(grid), isEnd), cost: 8},
+		"AStar":    {result: search.AStar(start, edges(grid), isEnd, manhattan), cost: 8},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if !test.result.Found || test.result.Goal != end {
+				t.Fatalf("expected to reach %v", end)
+			}
+			if test.result.Cost() != test.cost {
+				t.Errorf("expected cost %d, got %d", test.cost, test.result.Cost())
+			}
+
+			// The path goes from start to end, through neighbors.
+			path := test.result.Path(end)
+			if path[0] != start || path[len(path)-1] != end {
+				t.Errorf("expected path from %v to %v, got %v", start, end, path)
+			}
+			for i := 1; i < len(path); i++ {
+				if path[i].ManhattanDistance(path[i-1]) != 1 {
+					t.Errorf("expected path through neighbors, got %v", path)
+				}
+			}
+		})
+	}
+
+	// The shortest path in steps goes through the 9, but the cheapest goes
+	// around it.
+	if path := tests["Dijkstra"].result.Path(end); strings.Contains(fmt.Sprint(path), "{1 0}") {
+		t.Errorf("expected path to avoid the 9, got %v", path)
+	}
+}
+
+func TestUnreachable(t *testing.T) {
+	grid, start, end := parseMaze(t, []string{"S#E"})
+
+	r := search.BFS(start, steps(grid), func(c helpers.Coord2D) bool { return c == end })
+	if r.Found || r.Path(end) != nil {
+		t.Errorf("expected %v to be unreachable", end)
+	}
+
+	d := search.FloydWarshall([]helpers.Coord2D{start, end}, edges(grid))
+	if _, ok := d.Distance(start, end); ok {
+		t.Errorf("expected no distance from %v to %v", start, end)
+	}
+}
+
+// openMaze returns a square maze with walls on one row out of two.
+func openMaze(size int) []string {
+	lines := make([]string, size)
+	for y := range lines {
+		row := []byte(strings.Repeat("1", size))
+		if y%2 == 1 {
+			for x := 0; x < size-1; x++ {
+				row[(x+y)%size] = '#'
+			}
+		}
+		lines[y] = string(row)
+	}
+	lines[0] = "S" + lines[0][1:]
+	lines[size-1] = lines[size-1][:size-1] + "E"
+	return lines
+}
+
+func BenchmarkSearches(b *testing.B) {
+	grid, start, end := parseMaze(b, openMaze(101))
+	isEnd := func(c helpers.Coord2D) bool { return c == end }
+	manhattan := func(c helpers.Coord2D) int { return c.ManhattanDistance(end) }
+
+	b.Run("BFS", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			search.BFS(start, steps(grid), isEnd)
+		}
+	})
+	b.Run("Dijkstra", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			search.Dijkstra(start, edges(grid), isEnd)
+		}
+	})
+	b.Run("AStar", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			search.AStar(start, edges(grid), isEnd, manhattan)
+		}
+	})
+}
+
+func BenchmarkFloydWarshall(b *testing.B) {
+	grid, _, _ := parseMaze(b, openMaze(11))
+	cells := grid.FindAll(func(r rune) bool { return r != '#' })
+
+	for n := 0; n < b.N; n++ {
+		search.FloydWarshall(cells, edges(grid))
+	}
+}
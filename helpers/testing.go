@@ -7,7 +7,8 @@ import (
 )
 
 // TestSolution tests whether s, when provided with input, provides the expected
-// answer.
+// answer. Like the outputs of examples, answers are compared without
+// surrounding whitespace.
 func TestSolution(t *testing.T, s Solution, inputFile, answerFile string) {
 	t.Helper()
 
@@ -20,6 +21,7 @@ func TestSolution(t *testing.T, s Solution, inputFile, answerFile string) {
 	if err != nil {
 		t.Fatalf("could not read answer file: %v", err)
 	}
+	answer = bytes.TrimSpace(answer)
 	if len(answer) == 0 {
 		t.Fatalf("\n👉 Write the answer to %s\n", answerFile)
 	}
@@ -31,7 +33,7 @@ func TestSolution(t *testing.T, s Solution, inputFile, answerFile string) {
 		t.Fatalf("error running solution: %v", err)
 	}
 
-	actual := w.Bytes()
+	actual := bytes.TrimSpace(w.Bytes())
 	if !bytes.Equal(answer, actual) {
 		t.Fatalf("did not get expected answer:\n\texpected: %q\n\tgot: %q", answer, actual)
 	}
@@ -0,0 +1,72 @@
+package aocclient
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// A cacheEntry is a response stored on disk.
+type cacheEntry struct {
+	URL          string `json:"url"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+	Body         []byte `json:"body"`
+}
+
+// cachePath returns the path to the file caching the response from url. Pages
+// differ from one user to the next, so the session cookie is part of the key.
+func (c *Client) cachePath(url string) string {
+	sum := sha256.Sum256([]byte(c.cookie + "\n" + url))
+	return filepath.Join(c.cacheDir, "http", hex.EncodeToString(sum[:])+".json")
+}
+
+// loadCache returns the cached response from url, or nil if there is none.
+func (c *Client) loadCache(url string) (*cacheEntry, error) {
+	path := c.cachePath(url)
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading cache: %w", err)
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		// A corrupted entry is as good as no entry.
+		return nil, nil
+	}
+
+	return &entry, nil
+}
+
+// storeCache writes a response to the cache, if the response can be validated
+// later on.
+func (c *Client) storeCache(url string, entry *cacheEntry) error {
+	if entry.ETag == "" && entry.LastModified == "" {
+		return nil
+	}
+
+	path := c.cachePath(url)
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encoding cache entry: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("creating directory %q: %w", filepath.Dir(path), err)
+	}
+
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("writing cache to file %q: %w", path, err)
+	}
+
+	return nil
+}
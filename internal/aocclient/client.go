@@ -0,0 +1,215 @@
+package aocclient
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultBaseURL is the address of the Advent of Code website.
+	DefaultBaseURL = "https://adventofcode.com"
+	// DefaultUserAgent identifies the requests sent by this repository's
+	// tools. Adding contact information to it is appreciated by the website's
+	// maintainers.
+	DefaultUserAgent = "github.com/fabienzucchet/adventofcode"
+	// DefaultInterval is the minimum delay between two requests.
+	DefaultInterval = 5 * time.Second
+)
+
+// A Config holds the settings of a Client. Zero values are replaced by
+// sensible defaults.
+type Config struct {
+	// The address of the Advent of Code website.
+	BaseURL string
+	// Session cookie for adventofcode.com.
+	Cookie string
+	// User-Agent header sent with every request.
+	UserAgent string
+	// Minimum delay between two requests, even from different processes.
+	// Use a negative value to disable throttling.
+	Interval time.Duration
+	// Directory where responses and throttling state are stored. Defaults to
+	// $XDG_CACHE_HOME/adventofcode, or its equivalent on your system.
+	CacheDir string
+	// Time source, replaceable in tests. Defaults to time.Now and time.Sleep.
+	Now   func() time.Time
+	Sleep func(time.Duration)
+}
+
+// A Client sends requests to adventofcode.com.
+type Client struct {
+	baseURL   string
+	cookie    string
+	userAgent string
+	interval  time.Duration
+	cacheDir  string
+	now       func() time.Time
+	sleep     func(time.Duration)
+
+	httpClient *http.Client
+}
+
+// New builds a client with the given configuration.
+func New(cfg Config) (*Client, error) {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(cfg.BaseURL, "/"),
+		cookie:     cfg.Cookie,
+		userAgent:  cfg.UserAgent,
+		interval:   cfg.Interval,
+		cacheDir:   cfg.CacheDir,
+		now:        cfg.Now,
+		sleep:      cfg.Sleep,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+
+	if c.baseURL == "" {
+		c.baseURL = DefaultBaseURL
+	}
+	if c.userAgent == "" {
+		c.userAgent = DefaultUserAgent
+	}
+	if c.interval == 0 {
+		c.interval = DefaultInterval
+	}
+	if c.now == nil {
+		c.now = time.Now
+	}
+	if c.sleep == nil {
+		c.sleep = time.Sleep
+	}
+	if c.cacheDir == "" {
+		dir, err := os.UserCacheDir()
+		if err != nil {
+			return nil, fmt.Errorf("finding cache directory: %w", err)
+		}
+		c.cacheDir = filepath.Join(dir, "adventofcode")
+	}
+
+	if err := os.MkdirAll(c.cacheDir, 0700); err != nil {
+		return nil, fmt.Errorf("creating cache directory %q: %w", c.cacheDir, err)
+	}
+
+	return c, nil
+}
+
+// BaseURL returns the address of the Advent of Code website.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// LoggedIn returns whether the client has a session cookie.
+func (c *Client) LoggedIn() bool {
+	return c.cookie != ""
+}
+
+// Now returns the current time, according to the client's time source.
+func (c *Client) Now() time.Time {
+	return c.now()
+}
+
+// Sleep pauses for duration d, according to the client's time source.
+func (c *Client) Sleep(d time.Duration) {
+	c.sleep(d)
+}
+
+// Get fetches the page at path and returns its body. If a previous response
+// is cached, the request is conditional and the cached body is returned when
+// the page has not changed.
+func (c *Client) Get(path string) ([]byte, error) {
+	u := c.baseURL + path
+
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("preparing GET request to %q: %w", u, err)
+	}
+
+	cached, err := c.loadCache(u)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, body, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		return cached.Body, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, newStatusError(u, resp.StatusCode, body)
+	}
+
+	err = c.storeCache(u, &cacheEntry{
+		URL:          u,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		Body:         body,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
+
+// Post sends form to path and returns the response's body.
+func (c *Client) Post(path string, form url.Values) ([]byte, error) {
+	u := c.baseURL + path
+
+	req, err := http.NewRequest("POST", u, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("preparing POST request to %q: %w", u, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, body, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newStatusError(u, resp.StatusCode, body)
+	}
+
+	return body, nil
+}
+
+// do sends req once the throttling delay has passed, and reads the response.
+func (c *Client) do(req *http.Request) (*http.Response, []byte, error) {
+	req.Header.Set("User-Agent", c.userAgent)
+	if c.cookie != "" {
+		req.AddCookie(&http.Cookie{Name: "session", Value: c.cookie})
+	}
+
+	if err := c.throttle(); err != nil {
+		return nil, nil, fmt.Errorf("throttling: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("sending %s request to %q: %w", req.Method, req.URL, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading response from %q: %w", req.URL, err)
+	}
+
+	return resp, body, nil
+}
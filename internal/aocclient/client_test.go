@@ -0,0 +1,201 @@
+package aocclient_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fabienzucchet/adventofcode/internal/aocclient"
+)
+
+// fakeClock is a time source that only moves forward when sleeping.
+type fakeClock struct {
+	now   time.Time
+	slept []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Sleep(d time.Duration) {
+	c.slept = append(c.slept, d)
+	c.now = c.now.Add(d)
+}
+
+func newTestClient(t *testing.T, server *httptest.Server, clock *fakeClock, interval time.Duration) *aocclient.Client {
+	t.Helper()
+
+	client, err := aocclient.New(aocclient.Config{
+		BaseURL:   server.URL,
+		Cookie:    "secret",
+		UserAgent: "tests (ops@example.com)",
+		Interval:  interval,
+		CacheDir:  t.TempDir(),
+		Now:       clock.Now,
+		Sleep:     clock.Sleep,
+	})
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+
+	return client
+}
+
+func TestGetCache(t *testing.T) {
+	var requests, notModified int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if ua := r.Header.Get("User-Agent"); ua != "tests (ops@example.com)" {
+			t.Errorf("unexpected User-Agent %q", ua)
+		}
+		if c, err := r.Cookie("session"); err != nil || c.Value != "secret" {
+			t.Errorf("missing session cookie")
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, "puzzle")
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server, &fakeClock{now: time.Now()}, -1)
+
+	for i := 0; i < 3; i++ {
+		body, err := client.Get("/2022/day/1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(body) != "puzzle" {
+			t.Errorf("expected %q, got %q", "puzzle", body)
+		}
+	}
+
+	if requests != 3 || notModified != 2 {
+		t.Errorf("expected 3 requests with 2 cache hits, got %d requests with %d cache hits", requests, notModified)
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	}))
+	defer server.Close()
+
+	clock := &fakeClock{now: time.Date(2022, time.December, 1, 5, 0, 0, 0, time.UTC)}
+	client := newTestClient(t, server, clock, 5*time.Second)
+
+	if _, err := client.Get("/"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	clock.now = clock.now.Add(2 * time.Second)
+	if _, err := client.Post("/", url.Values{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(clock.slept) != 1 || clock.slept[0] != 3*time.Second {
+		t.Errorf("expected to sleep 3s once, slept %v", clock.slept)
+	}
+}
+
+func TestThrottleLock(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	}))
+	defer server.Close()
+
+	cacheDir := t.TempDir()
+	lockPath := filepath.Join(cacheDir, "last-request.lock")
+
+	// An abandoned lock is broken.
+	if err := ioutil.WriteFile(lockPath, nil, 0600); err != nil {
+		t.Fatalf("could not write lock: %v", err)
+	}
+	abandoned := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(lockPath, abandoned, abandoned); err != nil {
+		t.Fatalf("could not age lock: %v", err)
+	}
+
+	clock := &fakeClock{now: time.Now()}
+	client, err := aocclient.New(aocclient.Config{
+		BaseURL:  server.URL,
+		Interval: 5 * time.Second,
+		CacheDir: cacheDir,
+		Now:      clock.Now,
+		Sleep: func(d time.Duration) {
+			// Other processes can reserve their requests while this one waits.
+			if _, err := os.Stat(lockPath); !os.IsNotExist(err) {
+				t.Errorf("expected lock to be released while sleeping, got %v", err)
+			}
+			clock.Sleep(d)
+		},
+	})
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Get("/"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(clock.slept) != 1 || clock.slept[0] != 5*time.Second {
+		t.Errorf("expected to sleep 5s once, slept %v", clock.slept)
+	}
+	if _, err := os.Stat(lockPath); !os.IsNotExist(err) {
+		t.Errorf("expected lock to be released, got %v", err)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/2022/day/1/input":
+			http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+		case "/2022/day/26":
+			http.Error(w, "404 Not Found", http.StatusNotFound)
+		case "/2022/day/1/answer":
+			http.Error(w, "Bad request", http.StatusBadRequest)
+		default:
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server, &fakeClock{now: time.Now()}, -1)
+
+	tests := map[string]struct {
+		path string
+		kind error
+	}{
+		"not logged in": {path: "/2022/day/1/input", kind: aocclient.ErrNotLoggedIn},
+		"not found":     {path: "/2022/day/26", kind: aocclient.ErrNotFound},
+		"bad request":   {path: "/2022/day/1/answer", kind: aocclient.ErrBadRequest},
+		"server error":  {path: "/", kind: nil},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := client.Get(test.path)
+
+			var statusErr *aocclient.StatusError
+			if !errors.As(err, &statusErr) {
+				t.Fatalf("expected a StatusError, got %v", err)
+			}
+			if test.kind != nil && !errors.Is(err, test.kind) {
+				t.Errorf("expected error to be %v, got %v", test.kind, err)
+			}
+			if test.kind == nil && errors.Unwrap(err) != nil {
+				t.Errorf("expected error of unknown kind, got %v", errors.Unwrap(err))
+			}
+		})
+	}
+}
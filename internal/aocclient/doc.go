@@ -0,0 +1,5 @@
+// Package aocclient provides a client for the adventofcode.com website that
+// follows the site's automation guidelines: requests identify their origin with
+// a User-Agent, are throttled across processes, and are cached whenever the
+// server allows it.
+package aocclient
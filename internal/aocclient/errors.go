@@ -0,0 +1,53 @@
+package aocclient
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrNotLoggedIn means adventofcode.com requires a valid session cookie.
+	ErrNotLoggedIn = errors.New("not logged in")
+	// ErrNotFound means the page does not exist, eg. the puzzle is not
+	// unlocked yet.
+	ErrNotFound = errors.New("not found")
+	// ErrBadRequest means adventofcode.com refused the request.
+	ErrBadRequest = errors.New("bad request")
+)
+
+// A StatusError is returned when adventofcode.com responds with an unexpected
+// status code. Use errors.Is to check whether it is one of ErrNotLoggedIn,
+// ErrNotFound, or ErrBadRequest.
+type StatusError struct {
+	URL        string
+	StatusCode int
+	// Body of the response.
+	Body string
+
+	kind error
+}
+
+func newStatusError(url string, statusCode int, body []byte) *StatusError {
+	e := &StatusError{URL: url, StatusCode: statusCode, Body: strings.TrimSpace(string(body))}
+
+	switch {
+	case strings.Contains(e.Body, "log in"):
+		e.kind = ErrNotLoggedIn
+	case statusCode == 404:
+		e.kind = ErrNotFound
+	case statusCode == 400:
+		e.kind = ErrBadRequest
+	}
+
+	return e
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("adventofcode.com responded to %q with %d: %s", e.URL, e.StatusCode, e.Body)
+}
+
+// Unwrap returns the kind of error, if it is known.
+func (e *StatusError) Unwrap() error {
+	return e.kind
+}
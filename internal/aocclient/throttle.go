@@ -0,0 +1,114 @@
+package aocclient
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// How long to wait for another process to release the lock.
+	lockTimeout = 10 * time.Second
+	// How long before a lock is considered abandoned. The lock is only held
+	// while reading and writing the time of the last request, never while
+	// waiting for the interval to pass.
+	staleLockAge = 30 * time.Second
+	// How often to check whether the lock was released.
+	lockPollInterval = 50 * time.Millisecond
+)
+
+// throttle waits until the configured interval has passed since the last
+// request sent by any process sharing the cache directory. The time of the new
+// request is reserved before waiting, so that other processes wait for it too.
+func (c *Client) throttle() error {
+	if c.interval < 0 {
+		return nil
+	}
+
+	statePath := filepath.Join(c.cacheDir, "last-request")
+
+	unlock, err := c.lock(statePath + ".lock")
+	if err != nil {
+		return err
+	}
+
+	next, err := reserve(statePath, c.now(), c.interval)
+	unlock()
+	if err != nil {
+		return err
+	}
+
+	if wait := next.Sub(c.now()); wait > 0 {
+		c.sleep(wait)
+	}
+
+	return nil
+}
+
+// reserve records the time of the next request in the state file at path: now,
+// or interval after the last request recorded, whichever is later.
+func reserve(path string, now time.Time, interval time.Duration) (time.Time, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return time.Time{}, fmt.Errorf("reading time of last request: %w", err)
+	}
+
+	next := now
+	if last, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(string(data))); err == nil && last.Add(interval).After(next) {
+		next = last.Add(interval)
+	}
+
+	if err := ioutil.WriteFile(path, []byte(next.Format(time.RFC3339Nano)), 0600); err != nil {
+		return time.Time{}, fmt.Errorf("writing time of last request: %w", err)
+	}
+
+	return next, nil
+}
+
+// lock creates the lock file at path, waiting for other processes to release
+// it if needed. The returned function releases the lock.
+func (c *Client) lock(path string) (func(), error) {
+	deadline := time.Now().Add(lockTimeout)
+
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("creating lock file %q: %w", path, err)
+		}
+
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > staleLockAge {
+			breakLock(path, info)
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("lock file %q is held by another process", path)
+		}
+		time.Sleep(lockPollInterval)
+	}
+}
+
+// breakLock removes the abandoned lock file at path, described by stale. The
+// file is first renamed, which only one process can do, so that a lock taken
+// by another process in the meantime is put back rather than removed.
+func breakLock(path string, stale os.FileInfo) {
+	moved := fmt.Sprintf("%s.stale-%d", path, os.Getpid())
+	if err := os.Rename(path, moved); err != nil {
+		// Another process broke the lock first.
+		return
+	}
+
+	if info, err := os.Stat(moved); err == nil && !os.SameFile(info, stale) {
+		// Link fails rather than replace a lock taken since.
+		os.Link(moved, path)
+	}
+	os.Remove(moved)
+}
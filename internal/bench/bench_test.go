@@ -0,0 +1,99 @@
+package bench
+
+import (
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fabienzucchet/adventofcode/helpers"
+)
+
+func TestPercentile(t *testing.T) {
+	values := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	tests := map[int]int{
+		0:   1,
+		50:  5,
+		95:  10,
+		100: 10,
+	}
+
+	for p, expected := range tests {
+		if actual := percentile(values, p); actual != expected {
+			t.Errorf("expected percentile %d to be %d, got %d", p, expected, actual)
+		}
+	}
+}
+
+func TestMeasure(t *testing.T) {
+	var runs int
+	s := helpers.SolutionFunc(func(input io.Reader, answer io.Writer) error {
+		runs++
+		_, err := ioutil.ReadAll(input)
+		return err
+	})
+
+	stats, err := Measure(s, []byte("input"), Options{Warmup: 2, MinSamples: 3, MaxSamples: 5, Budget: time.Hour})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if stats.Samples != 5 || runs != 7 {
+		t.Errorf("expected 2 warm-up runs and 5 samples, got %d runs and %d samples", runs, stats.Samples)
+	}
+	if stats.Median > stats.P95 {
+		t.Errorf("expected median %s to be at most p95 %s", stats.Median, stats.P95)
+	}
+}
+
+func TestMeasurePanic(t *testing.T) {
+	s := helpers.SolutionFunc(func(input io.Reader, answer io.Writer) error {
+		panic("index out of range")
+	})
+
+	_, err := Measure(s, []byte("input"), DefaultOptions)
+	if err == nil || !strings.Contains(err.Error(), "panic: index out of range") {
+		t.Errorf("expected the panic as an error, got %v", err)
+	}
+}
+
+func TestRegressions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "benchmarks.json")
+
+	h, err := LoadHistory(path)
+	if err != nil {
+		t.Fatalf("could not load history: %v", err)
+	}
+
+	h.Record("abc123", time.Now(), map[string]Stats{
+		"slower":  {Median: 10 * time.Millisecond},
+		"noise":   {Median: 10 * time.Microsecond},
+		"similar": {Median: 10 * time.Millisecond},
+	})
+	h.Baseline = "abc123"
+	if err := h.Save(path); err != nil {
+		t.Fatalf("could not save history: %v", err)
+	}
+
+	h, err = LoadHistory(path)
+	if err != nil {
+		t.Fatalf("could not load saved history: %v", err)
+	}
+
+	regressions := h.Regressions(map[string]Stats{
+		"slower":  {Median: 15 * time.Millisecond},
+		"noise":   {Median: 30 * time.Microsecond},
+		"similar": {Median: 11 * time.Millisecond},
+		"new":     {Median: time.Second},
+	}, 0.2)
+
+	if len(regressions) != 1 || regressions[0].Name != "slower" {
+		t.Fatalf("expected only %q to regress, got %v", "slower", regressions)
+	}
+	if s := regressions[0].Slowdown(); s < 0.49 || s > 0.51 {
+		t.Errorf("expected slowdown of 50%%, got %.0f%%", s*100)
+	}
+}
@@ -0,0 +1,3 @@
+// Package bench measures the performance of solutions, and keeps track of
+// measurements across commits to detect regressions.
+package bench
@@ -0,0 +1,110 @@
+package bench
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/fabienzucchet/adventofcode/helpers"
+)
+
+// Options control how solutions are measured.
+type Options struct {
+	// Number of runs before measurements start.
+	Warmup int
+	// Bounds on the number of measured runs.
+	MinSamples, MaxSamples int
+	// Once this much time is spent measuring, stop as soon as MinSamples runs
+	// are done.
+	Budget time.Duration
+}
+
+// DefaultOptions are sensible options for solutions running anywhere between
+// microseconds and seconds.
+var DefaultOptions = Options{
+	Warmup:     1,
+	MinSamples: 3,
+	MaxSamples: 50,
+	Budget:     time.Second,
+}
+
+// Stats are the measured performance of a solution.
+type Stats struct {
+	Samples int           `json:"samples"`
+	Median  time.Duration `json:"median_ns"`
+	P95     time.Duration `json:"p95_ns"`
+	// Median number and size of heap allocations per run.
+	Allocs uint64 `json:"allocs"`
+	Bytes  uint64 `json:"bytes"`
+}
+
+// Measure runs s on input repeatedly and returns statistics on its run time
+// and allocations.
+func Measure(s helpers.Solution, input []byte, opts Options) (Stats, error) {
+	r := bytes.NewReader(input)
+	// Panics are turned into errors, so that one solution does not abort the
+	// measurement of the others.
+	run := func() (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = fmt.Errorf("panic: %v", p)
+			}
+		}()
+		r.Reset(input)
+		return s.Solve(r, ioutil.Discard)
+	}
+
+	for i := 0; i < opts.Warmup; i++ {
+		if err := run(); err != nil {
+			return Stats{}, fmt.Errorf("running solution: %w", err)
+		}
+	}
+
+	var (
+		durations     []time.Duration
+		allocs, sizes []uint64
+		before, after runtime.MemStats
+		spent         time.Duration
+	)
+	for len(durations) < opts.MaxSamples && (len(durations) < opts.MinSamples || spent < opts.Budget) {
+		runtime.ReadMemStats(&before)
+		start := time.Now()
+		err := run()
+		elapsed := time.Since(start)
+		runtime.ReadMemStats(&after)
+
+		if err != nil {
+			return Stats{}, fmt.Errorf("running solution: %w", err)
+		}
+
+		durations = append(durations, elapsed)
+		allocs = append(allocs, after.Mallocs-before.Mallocs)
+		sizes = append(sizes, after.TotalAlloc-before.TotalAlloc)
+		spent += elapsed
+	}
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	sort.Slice(allocs, func(i, j int) bool { return allocs[i] < allocs[j] })
+	sort.Slice(sizes, func(i, j int) bool { return sizes[i] < sizes[j] })
+
+	return Stats{
+		Samples: len(durations),
+		Median:  percentile(durations, 50),
+		P95:     percentile(durations, 95),
+		Allocs:  percentile(allocs, 50),
+		Bytes:   percentile(sizes, 50),
+	}, nil
+}
+
+// percentile returns the p-th percentile of sorted values, using the
+// nearest-rank method.
+func percentile[T any](sorted []T, p int) T {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
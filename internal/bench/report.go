@@ -0,0 +1,119 @@
+package bench
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"time"
+)
+
+// minDelta is the smallest slowdown considered a regression. Below this,
+// differences are mostly noise.
+const minDelta = 100 * time.Microsecond
+
+// A Run holds the measurements of solutions at one commit.
+type Run struct {
+	Date time.Time `json:"date"`
+	// Measurements, indexed by solution (eg. "2022/10/fabienz part 1").
+	Results map[string]Stats `json:"results"`
+}
+
+// A History holds benchmark runs, indexed by git commit.
+type History struct {
+	// Commit that runs are compared against.
+	Baseline string         `json:"baseline,omitempty"`
+	Runs     map[string]Run `json:"runs"`
+}
+
+// LoadHistory reads the history stored in the file at path. If the file does
+// not exist, the history is empty.
+func LoadHistory(path string) (*History, error) {
+	h := &History{Runs: make(map[string]Run)}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading history: %w", err)
+	}
+
+	if err := json.Unmarshal(data, h); err != nil {
+		return nil, fmt.Errorf("parsing history %q: %w", path, err)
+	}
+	if h.Runs == nil {
+		h.Runs = make(map[string]Run)
+	}
+
+	return h, nil
+}
+
+// Save writes the history to the file at path.
+func (h *History) Save(path string) error {
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding history: %w", err)
+	}
+
+	if err := ioutil.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("writing history to file %q: %w", path, err)
+	}
+
+	return nil
+}
+
+// Record adds the results of a run at commit to the history. Results of
+// solutions that were not measured this time are kept from previous runs at
+// the same commit.
+func (h *History) Record(commit string, date time.Time, results map[string]Stats) {
+	run, ok := h.Runs[commit]
+	if !ok {
+		run = Run{Results: make(map[string]Stats)}
+	}
+
+	run.Date = date
+	for name, stats := range results {
+		run.Results[name] = stats
+	}
+
+	h.Runs[commit] = run
+}
+
+// A Regression is a solution that became slower than its baseline.
+type Regression struct {
+	Name             string
+	Baseline, Actual Stats
+}
+
+// Slowdown returns how much slower the solution became, as a ratio.
+func (r Regression) Slowdown() float64 {
+	return float64(r.Actual.Median)/float64(r.Baseline.Median) - 1
+}
+
+// Regressions compares results to the baseline run, and returns solutions
+// whose median run time grew by more than threshold (eg. 0.2 for 20%).
+func (h *History) Regressions(results map[string]Stats, threshold float64) []Regression {
+	baseline, ok := h.Runs[h.Baseline]
+	if !ok {
+		return nil
+	}
+
+	var regressions []Regression
+	for name, actual := range results {
+		base, ok := baseline.Results[name]
+		if !ok || base.Median <= 0 {
+			continue
+		}
+
+		r := Regression{Name: name, Baseline: base, Actual: actual}
+		if actual.Median-base.Median > minDelta && r.Slowdown() > threshold {
+			regressions = append(regressions, r)
+		}
+	}
+
+	sort.Slice(regressions, func(i, j int) bool { return regressions[i].Name < regressions[j].Name })
+
+	return regressions
+}
@@ -0,0 +1,190 @@
+package compare
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/fabienzucchet/adventofcode/helpers"
+	"github.com/fabienzucchet/adventofcode/internal/bench"
+	"github.com/fabienzucchet/adventofcode/internal/solutions"
+)
+
+// An Answer is the output of a solution run on an input.
+type Answer struct {
+	Value string
+	Err   error
+}
+
+// A Matrix holds the answers of every author's solution to every author's
+// input, for one part of a puzzle.
+type Matrix struct {
+	Part int
+	// Authors of the solutions, sorted.
+	Solutions []string
+	// Authors of the inputs, sorted.
+	Inputs []string
+	// Answers, indexed by the author of the solution then of the input.
+	Answers map[string]map[string]Answer
+}
+
+// Disagreements returns the authors of the inputs on which solutions give
+// different answers, or fail.
+func (m *Matrix) Disagreements() []string {
+	var authors []string
+	for _, input := range m.Inputs {
+		values := make(map[string]bool)
+		failed := false
+		for _, solution := range m.Solutions {
+			a := m.Answers[solution][input]
+			if a.Err != nil {
+				failed = true
+			}
+			values[a.Value] = true
+		}
+		if failed || len(values) > 1 {
+			authors = append(authors, input)
+		}
+	}
+	return authors
+}
+
+// A Benchmark is the performance of an author's solution on their own input.
+type Benchmark struct {
+	Author string
+	Part   int
+	Stats  bench.Stats
+	// Error of the solution, if it failed.
+	Err error
+}
+
+// Puzzle gathers the solutions and inputs of all authors for one puzzle.
+type Puzzle struct {
+	Year, Day int
+	// Inputs of each author, indexed by author.
+	inputs map[string][]byte
+	// Solutions of each author, indexed by part then author.
+	solutions map[int]map[string]helpers.Solution
+}
+
+// Load finds all solutions registered for the puzzle of the given day and
+// year, and reads their authors' inputs in workdir.
+func Load(year, day int, workdir string) (*Puzzle, error) {
+	p := &Puzzle{
+		Year:      year,
+		Day:       day,
+		inputs:    make(map[string][]byte),
+		solutions: make(map[int]map[string]helpers.Solution),
+	}
+
+	for _, key := range solutions.Keys() {
+		if key.Year != year || key.Day != day {
+			continue
+		}
+
+		s, err := solutions.Lookup(key)
+		if err != nil {
+			return nil, err
+		}
+		if p.solutions[key.Part] == nil {
+			p.solutions[key.Part] = make(map[string]helpers.Solution)
+		}
+		p.solutions[key.Part][key.Author] = s
+
+		if _, ok := p.inputs[key.Author]; ok {
+			continue
+		}
+		input, err := ioutil.ReadFile(filepath.Join(workdir, key.InputFile()))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading input of %s: %w", key.Author, err)
+		}
+		p.inputs[key.Author] = input
+	}
+
+	if len(p.solutions) == 0 {
+		return nil, fmt.Errorf("no solution registered for day %d of %d", day, year)
+	}
+
+	return p, nil
+}
+
+// Parts returns the parts of the puzzle that have solutions, sorted.
+func (p *Puzzle) Parts() []int {
+	var parts []int
+	for part := range p.solutions {
+		parts = append(parts, part)
+	}
+	sort.Ints(parts)
+	return parts
+}
+
+// Answers runs every author's solution to part against every author's input.
+func (p *Puzzle) Answers(part int) *Matrix {
+	m := &Matrix{
+		Part:      part,
+		Solutions: sortedKeys(p.solutions[part]),
+		Inputs:    sortedKeys(p.inputs),
+		Answers:   make(map[string]map[string]Answer),
+	}
+
+	for _, solution := range m.Solutions {
+		m.Answers[solution] = make(map[string]Answer)
+		for _, input := range m.Inputs {
+			value, err := solve(p.solutions[part][solution], p.inputs[input])
+			m.Answers[solution][input] = Answer{Value: value, Err: err}
+		}
+	}
+
+	return m
+}
+
+// Benchmarks measures the performance of every author's solution to part on
+// their own input.
+func (p *Puzzle) Benchmarks(part int) []Benchmark {
+	var benchmarks []Benchmark
+
+	for _, author := range sortedKeys(p.solutions[part]) {
+		input, ok := p.inputs[author]
+		if !ok {
+			continue
+		}
+		s := p.solutions[part][author]
+
+		stats, err := bench.Measure(s, input, bench.DefaultOptions)
+		benchmarks = append(benchmarks, Benchmark{Author: author, Part: part, Stats: stats, Err: err})
+	}
+
+	return benchmarks
+}
+
+// solve runs s on input. Solutions may not expect inputs from other authors,
+// so panics are turned into errors.
+func solve(s helpers.Solution, input []byte) (answer string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	w := &bytes.Buffer{}
+	if err := s.Solve(bytes.NewReader(input), w); err != nil {
+		return "", err
+	}
+
+	return w.String(), nil
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,68 @@
+package compare
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/fabienzucchet/adventofcode/helpers"
+)
+
+// sum adds up the integers in its input, separated by spaces.
+func sum(skipNegatives bool) helpers.SolutionFunc {
+	return func(input io.Reader, answer io.Writer) error {
+		total := 0
+		for {
+			var n int
+			if _, err := fmt.Fscan(input, &n); err == io.EOF {
+				break
+			} else if err != nil {
+				return err
+			}
+			if n < 0 && skipNegatives {
+				continue
+			}
+			total += n
+		}
+		_, err := fmt.Fprint(answer, total)
+		return err
+	}
+}
+
+func TestAnswers(t *testing.T) {
+	p := &Puzzle{
+		inputs: map[string][]byte{
+			"alice": []byte("1 2 3"),
+			"bob":   []byte("4 -5 6"),
+			"carol": []byte("7 eight"),
+		},
+		solutions: map[int]map[string]helpers.Solution{
+			1: {
+				"alice": sum(false),
+				"bob":   sum(true),
+				"dave": helpers.SolutionFunc(func(io.Reader, io.Writer) error {
+					panic("not implemented")
+				}),
+			},
+		},
+	}
+
+	m := p.Answers(1)
+
+	if expected := []string{"alice", "bob", "dave"}; !reflect.DeepEqual(m.Solutions, expected) {
+		t.Errorf("expected solutions %v, got %v", expected, m.Solutions)
+	}
+	if a := m.Answers["bob"]["bob"]; a.Value != "10" || a.Err != nil {
+		t.Errorf("expected bob's solution to answer 10 on bob's input, got %q (error: %v)", a.Value, a.Err)
+	}
+	if a := m.Answers["dave"]["alice"]; a.Err == nil {
+		t.Error("expected panic to be turned into an error")
+	}
+
+	delete(m.Answers, "dave")
+	m.Solutions = m.Solutions[:2]
+	if expected := []string{"bob", "carol"}; !reflect.DeepEqual(m.Disagreements(), expected) {
+		t.Errorf("expected disagreements on %v, got %v", expected, m.Disagreements())
+	}
+}
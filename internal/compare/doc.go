@@ -0,0 +1,4 @@
+// Package compare runs the solutions of several authors to the same puzzle
+// against each other's inputs. Solutions that disagree on an input reveal bugs
+// that only show with some inputs.
+package compare
@@ -0,0 +1,176 @@
+package scaffolding
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// partNames maps puzzle parts to the names used in solutions and tests.
+var partNames = map[int]string{
+	1: "One",
+	2: "Two",
+}
+
+// AnswerFile returns the path to the file holding the answer to part of the
+// puzzle solved in packageDir.
+func AnswerFile(packageDir string, part int) string {
+	name := fmt.Sprintf("part-%s-answer.txt", strings.ToLower(partNames[part]))
+	return filepath.Join(packageDir, "testdata", name)
+}
+
+// AcceptAnswer records answer as the right answer to part of the puzzle solved
+// in packageDir. The answer is written to the output comment of the example
+// test for that part, and to the answer file read by helpers.TestSolution. If
+// overwrite is false, AcceptAnswer refuses to replace a different answer.
+// Answers are compared and written without surrounding whitespace.
+func AcceptAnswer(packageDir string, part int, answer string, overwrite bool) error {
+	name, ok := partNames[part]
+	if !ok {
+		return fmt.Errorf("invalid part: %d", part)
+	}
+	answer = strings.TrimSpace(answer)
+
+	testPath := filepath.Join(packageDir, "solution_test.go")
+	src, err := ioutil.ReadFile(testPath)
+	if err != nil {
+		return fmt.Errorf("reading tests: %w", err)
+	}
+
+	newSrc, previous, err := rewriteExampleOutput(src, "ExamplePart"+name, answer)
+	if err != nil {
+		return fmt.Errorf("updating %q: %w", testPath, err)
+	}
+
+	answerPath := AnswerFile(packageDir, part)
+	existing, err := ioutil.ReadFile(answerPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("reading answer file: %w", err)
+	}
+
+	if !overwrite {
+		if previous != answerPlaceholder && previous != answer {
+			return fmt.Errorf("ExamplePart%s already expects a different answer: %q", name, previous)
+		}
+		if known := strings.TrimSpace(string(existing)); known != "" && known != answer {
+			return fmt.Errorf("%s already holds a different answer: %q", answerPath, existing)
+		}
+	}
+
+	if err := ioutil.WriteFile(testPath, newSrc, 0644); err != nil {
+		return fmt.Errorf("writing tests to file %q: %w", testPath, err)
+	}
+	fmt.Printf("  👉 Updated ExamplePart%s.\n", name)
+
+	if err := os.MkdirAll(filepath.Dir(answerPath), 0755); err != nil {
+		return fmt.Errorf("creating directory %q: %w", filepath.Dir(answerPath), err)
+	}
+	if err := ioutil.WriteFile(answerPath, []byte(answer), 0644); err != nil {
+		return fmt.Errorf("writing answer to file %q: %w", answerPath, err)
+	}
+	fmt.Printf("  👉 Wrote %s.\n", filepath.Base(answerPath))
+
+	return nil
+}
+
+// rewriteExampleOutput replaces the output comment of the example function
+// funcName in src with answer. It returns the updated source code, as well as
+// the output the example expected before.
+func rewriteExampleOutput(src []byte, funcName, answer string) ([]byte, string, error) {
+	start, end, previous, err := findExampleOutput(src, funcName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	answerLines := strings.Split(strings.TrimSpace(answer), "\n")
+	var comment string
+	if len(answerLines) == 1 {
+		comment = "// Output: " + answerLines[0]
+	} else {
+		comment = "// Output:\n// " + strings.Join(answerLines, "\n// ")
+	}
+
+	var buf bytes.Buffer
+	buf.Write(src[:start])
+	buf.WriteString(comment)
+	buf.Write(src[end:])
+
+	newSrc, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, "", fmt.Errorf("formatting source: %w", err)
+	}
+
+	return newSrc, previous, nil
+}
+
+// findExampleOutput locates the output comment of the example function
+// funcName in src. It returns the offsets of the comment in src, and the output
+// it expects.
+func findExampleOutput(src []byte, funcName string) (start, end int, output string, err error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("parsing source: %w", err)
+	}
+
+	fn := findFunc(f, funcName)
+	if fn == nil || fn.Body == nil {
+		return 0, 0, "", fmt.Errorf("no %s function", funcName)
+	}
+
+	// The output comment is the last comment of the function's body starting
+	// with "Output:", and runs until the end of its comment group.
+	var (
+		group *ast.CommentGroup
+		first int
+	)
+	for _, cg := range f.Comments {
+		if cg.Pos() < fn.Body.Lbrace || cg.End() > fn.Body.Rbrace {
+			continue
+		}
+		for i, c := range cg.List {
+			if strings.HasPrefix(commentText(c), "Output:") {
+				group, first = cg, i
+			}
+		}
+	}
+	if group == nil {
+		return 0, 0, "", fmt.Errorf("no output comment in %s", funcName)
+	}
+
+	var lines []string
+	for _, c := range group.List[first:] {
+		lines = append(lines, commentText(c))
+	}
+	lines[0] = strings.TrimPrefix(lines[0], "Output:")
+	output = strings.TrimSpace(strings.Join(lines, "\n"))
+
+	start = fset.Position(group.List[first].Pos()).Offset
+	end = fset.Position(group.End()).Offset
+
+	return start, end, output, nil
+}
+
+// findFunc returns the top-level function named name in f, or nil if there is
+// none.
+func findFunc(f *ast.File, name string) *ast.FuncDecl {
+	for _, decl := range f.Decls {
+		if d, ok := decl.(*ast.FuncDecl); ok && d.Recv == nil && d.Name.Name == name {
+			return d
+		}
+	}
+	return nil
+}
+
+// commentText returns the text of a line comment, without the comment marker
+// and surrounding spaces.
+func commentText(c *ast.Comment) string {
+	return strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+}
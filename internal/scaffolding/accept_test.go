@@ -0,0 +1,92 @@
+package scaffolding
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+const exampleTests = `package fabienz
+
+func ExamplePartOne() {
+	// Output: 👉 Write the answer here 👈
+}
+
+func ExamplePartTwo() {
+	// Some notes.
+
+	// ###.
+	// #..#
+	// Output: ABCD
+}
+`
+
+func TestRewriteExampleOutput(t *testing.T) {
+	tests := map[string]struct {
+		funcName, answer string
+		previous         string
+		contains         string
+	}{
+		"placeholder": {
+			funcName: "ExamplePartOne",
+			answer:   "14060",
+			previous: answerPlaceholder,
+			contains: "\t// Output: 14060\n}",
+		},
+		"multiline answer": {
+			funcName: "ExamplePartTwo",
+			answer:   "###.\n#..#\n",
+			previous: "ABCD",
+			contains: "\t// Output:\n\t// ###.\n\t// #..#\n}",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			src, previous, err := rewriteExampleOutput([]byte(exampleTests), test.funcName, test.answer)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if previous != test.previous {
+				t.Errorf("expected previous output %q, got %q", test.previous, previous)
+			}
+			if !strings.Contains(string(src), test.contains) {
+				t.Errorf("expected source to contain %q, got:\n%s", test.contains, src)
+			}
+			if !strings.Contains(string(src), "// Some notes.") {
+				t.Errorf("expected other comments to be kept, got:\n%s", src)
+			}
+		})
+	}
+
+	if _, _, err := rewriteExampleOutput([]byte(exampleTests), "ExamplePartThree", "1"); err == nil {
+		t.Error("expected error for missing function")
+	}
+}
+
+func TestAcceptAnswer(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"solution_test.go":             exampleTests,
+		"testdata/part-one-answer.txt": "14060\n",
+	})
+
+	// The answers only differ by surrounding whitespace.
+	if err := AcceptAnswer(dir, 1, " 14060\n", false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	answer, err := ioutil.ReadFile(AnswerFile(dir, 1))
+	if err != nil {
+		t.Fatalf("could not read answer file: %v", err)
+	}
+	if string(answer) != "14060" {
+		t.Errorf("expected answer file to hold %q, got %q", "14060", answer)
+	}
+
+	if err := AcceptAnswer(dir, 1, "14061", false); err == nil {
+		t.Error("expected error when replacing a different answer")
+	}
+	if err := AcceptAnswer(dir, 1, "14061", true); err != nil {
+		t.Errorf("unexpected error when forcing a different answer: %v", err)
+	}
+}
@@ -0,0 +1,181 @@
+package scaffolding
+
+import (
+	"fmt"
+	"html"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	// Blocks of code, which hold example inputs.
+	preCodeRegexp = regexp.MustCompile(`(?s)<pre><code>(.*?)</code></pre>`)
+	// Emphasized code, which is how answers to examples are highlighted.
+	highlightedCodeRegexp = regexp.MustCompile(`<code><em>([^<]*)</em></code>`)
+	// The puzzle's question, eg. "What is the sum of these signal strengths?"
+	questionRegexp = regexp.MustCompile(`(?i)<em>[^<]*\b(?:what|how many|which)\b[^<]*\?</em>`)
+)
+
+// An example is a small input shown in a puzzle's description, along with the
+// answers the description gives for it.
+type example struct {
+	// Name of the example, eg. "example-1".
+	Name string
+	// Contents of the example input.
+	Input string
+	// Answers for each part of the puzzle the example applies to. An empty
+	// answer means the part applies to the example but its answer is unknown.
+	Answers map[int]string
+}
+
+// extractExamples finds examples in the page of a puzzle. The example input of
+// each part is assumed to be the first block of code of the part's
+// description, and its answer the last highlighted code before the puzzle's
+// question, if any. When only the first part is unlocked, the second part is
+// assumed to use the same example.
+func extractExamples(page string) []example {
+	var examples []example
+
+	articles := puzzleArticles(page)
+	for i, article := range articles {
+		part := i + 1
+
+		var input string
+		if m := preCodeRegexp.FindStringSubmatch(article); m != nil {
+			input = html.UnescapeString(tagRegexp.ReplaceAllString(m[1], ""))
+		} else if len(examples) > 0 {
+			input = examples[0].Input
+		} else {
+			continue
+		}
+
+		ex := findExample(examples, input)
+		if ex == nil {
+			examples = append(examples, example{
+				Name:    fmt.Sprintf("example-%d", len(examples)+1),
+				Input:   input,
+				Answers: make(map[int]string),
+			})
+			ex = &examples[len(examples)-1]
+		}
+
+		ex.Answers[part] = exampleAnswer(article)
+	}
+
+	if len(articles) == 1 && len(examples) > 0 {
+		examples[0].Answers[2] = ""
+	}
+
+	return examples
+}
+
+// findExample returns the example with the given input, if there is one.
+func findExample(examples []example, input string) *example {
+	for i := range examples {
+		if examples[i].Input == input {
+			return &examples[i]
+		}
+	}
+	return nil
+}
+
+// exampleAnswer returns the answer given for the example in the description
+// of one part of a puzzle, or an empty string if it cannot be found.
+func exampleAnswer(article string) string {
+	if loc := questionRegexp.FindStringIndex(article); loc != nil {
+		if answer := lastHighlightedCode(article[:loc[0]]); answer != "" {
+			return answer
+		}
+	}
+
+	return lastHighlightedCode(article)
+}
+
+// lastHighlightedCode returns the contents of the last highlighted code in s.
+func lastHighlightedCode(s string) string {
+	matches := highlightedCodeRegexp.FindAllStringSubmatch(s, -1)
+	if len(matches) == 0 {
+		return ""
+	}
+
+	return html.UnescapeString(matches[len(matches)-1][1])
+}
+
+// examplesForPart returns the names of the examples that apply to part.
+func examplesForPart(examples []example, part int) []string {
+	var names []string
+	for _, ex := range examples {
+		if _, ok := ex.Answers[part]; ok {
+			names = append(names, ex.Name)
+		}
+	}
+	return names
+}
+
+// exampleAnswerFile returns the name of the file holding the answer to part for
+// the example.
+func exampleAnswerFile(name string, part int) string {
+	return fmt.Sprintf("%s-part-%s-answer.txt", name, strings.ToLower(partNames[part]))
+}
+
+// WriteExamples writes the examples found in the puzzle's description to the
+// testdata directory, along with their answers. Answers that could not be
+// found are left empty, for the user to fill in.
+func (gen *Generator) WriteExamples() error {
+	if len(gen.examples) == 0 {
+		if gen.puzzlePage != "" {
+			fmt.Println("  👉 Skipping examples; none found in description.")
+		}
+		return nil
+	}
+
+	dir := filepath.Join(gen.packageDir, "testdata")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("creating directory %q: %w", dir, err)
+	}
+
+	for _, ex := range gen.examples {
+		path := filepath.Join(dir, ex.Name+".txt")
+		if !fileExists(path) || gen.overwrite {
+			if err := ioutil.WriteFile(path, []byte(ex.Input), 0644); err != nil {
+				return fmt.Errorf("writing example to file %q: %w", path, err)
+			}
+		}
+
+		parts := make([]int, 0, len(ex.Answers))
+		for part := range ex.Answers {
+			parts = append(parts, part)
+		}
+		sort.Ints(parts)
+
+		for _, part := range parts {
+			answer := ex.Answers[part]
+			path := filepath.Join(dir, exampleAnswerFile(ex.Name, part))
+
+			// Answers left empty are filled in when the description is
+			// downloaded again.
+			existing, err := ioutil.ReadFile(path)
+			if err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("reading answer file: %w", err)
+			}
+			if err == nil && len(existing) > 0 && !gen.overwrite {
+				continue
+			}
+			if err == nil && answer == "" {
+				continue
+			}
+
+			if err := ioutil.WriteFile(path, []byte(answer), 0644); err != nil {
+				return fmt.Errorf("writing answer to file %q: %w", path, err)
+			}
+		}
+
+		fmt.Printf("  👉 Wrote %s.\n", ex.Name)
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package scaffolding
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractExamples(t *testing.T) {
+	examples := extractExamples(puzzlePage)
+
+	expected := []example{
+		{
+			Name:    "example-1",
+			Input:   "1000\n2000\n\n3000\n",
+			Answers: map[int]string{1: "24000", 2: ""},
+		},
+	}
+
+	if !reflect.DeepEqual(examples, expected) {
+		t.Errorf("unexpected examples:\n%#v\nexpected:\n%#v", examples, expected)
+	}
+
+	if names := examplesForPart(examples, 2); !reflect.DeepEqual(names, []string{"example-1"}) {
+		t.Errorf("unexpected examples for part two: %v", names)
+	}
+}
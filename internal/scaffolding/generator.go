@@ -1,20 +1,22 @@
 package scaffolding
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"go/format"
 	"html/template"
 	"io/ioutil"
-	"net/http"
 	"os"
 	"path/filepath"
 	"regexp"
 
+	"github.com/fabienzucchet/adventofcode/internal/aocclient"
 	"golang.org/x/mod/modfile"
 )
 
 const (
-	validAuthorPattern = "[a-z]+"
+	validAuthorPattern = "^[a-z]+$"
 )
 
 var (
@@ -30,10 +32,23 @@ type Generator struct {
 	author string
 	// The directory where all Advent of Code solutions are stored.
 	workdir string
-	// Session cookie for adventofcode.com.
-	cookie string
+	// Client for adventofcode.com.
+	client *aocclient.Client
 	// Whether to overwrite existing files.
 	overwrite bool
+	// Whether to download the puzzle description again, to get the second
+	// part of the puzzle once it is unlocked.
+	refresh bool
+	// Whether to wait for the puzzle to be unlocked before downloading it.
+	wait bool
+
+	// Page of the puzzle on adventofcode.com, if it was downloaded.
+	puzzlePage string
+	// Examples found in the puzzle's description.
+	examples []example
+	// Contents of the files written by the generator, so they can be updated
+	// as long as they have not been edited.
+	written map[string][]byte
 
 	// Path to scaffolded directory.
 	packageDir string
@@ -42,15 +57,20 @@ type Generator struct {
 }
 
 // NewGenerator builds a generator for the given date and author. If overwrite
-// is true, the generator will overwrite existing files.
-func NewGenerator(day, year int, author, workdir, cookie string, overwrite bool) (*Generator, error) {
+// is true, the generator will overwrite existing files. If refresh is true, the
+// generator will download the puzzle description again. If wait is true, the
+// generator will wait for the puzzle to be unlocked.
+func NewGenerator(day, year int, author, workdir string, client *aocclient.Client, overwrite, refresh, wait bool) (*Generator, error) {
 	gen := &Generator{
 		day:       day,
 		year:      year,
 		author:    author,
 		workdir:   workdir,
-		cookie:    cookie,
+		client:    client,
 		overwrite: overwrite,
+		refresh:   refresh,
+		wait:      wait,
+		written:   make(map[string][]byte),
 	}
 
 	if err := gen.Initialize(); err != nil {
@@ -74,6 +94,9 @@ func (gen *Generator) Initialize() error {
 	if gen.workdir == "" {
 		return errors.New("working directory unknown")
 	}
+	if gen.client == nil {
+		return errors.New("no client for adventofcode.com")
+	}
 	if err := gen.setModulePath(); err != nil {
 		return fmt.Errorf("unknown module path: %w", err)
 	}
@@ -87,12 +110,31 @@ func (gen *Generator) Run() error {
 	if err := gen.CreatePackage(); err != nil {
 		return fmt.Errorf("creating package: %w", err)
 	}
+	if gen.wait {
+		if err := gen.WaitForUnlock(); err != nil {
+			return fmt.Errorf("waiting for puzzle: %w", err)
+		}
+	}
+	// The code is scaffolded even without the puzzle, for instance offline:
+	// only its description and examples are missing.
+	if err := gen.DownloadPuzzle(); err != nil {
+		fmt.Printf("  ⚠️  Could not download puzzle, scaffolding without examples: %v\n", err)
+	}
 	if err := gen.WriteCode(); err != nil {
 		return fmt.Errorf("writing code: %w", err)
 	}
+	if err := gen.UpdateRegistry(); err != nil {
+		return fmt.Errorf("updating registry: %w", err)
+	}
 	if err := gen.DownloadInput(); err != nil {
 		return fmt.Errorf("downloading input: %w", err)
 	}
+	if err := gen.WriteDescription(); err != nil {
+		return fmt.Errorf("writing description: %w", err)
+	}
+	if err := gen.WriteExamples(); err != nil {
+		return fmt.Errorf("writing examples: %w", err)
+	}
 	return nil
 }
 
@@ -128,64 +170,108 @@ func (gen *Generator) DownloadInput() error {
 		return nil
 	}
 
-	if gen.cookie == "" {
+	if !gen.client.LoggedIn() {
 		fmt.Println("  👉 Skipping input download; no session cookie provided.")
 		return nil
 	}
 
-	url := fmt.Sprintf("https://adventofcode.com/%d/day/%d/input", gen.year, gen.day)
-
-	req, err := http.NewRequest("GET", url, nil)
+	input, err := gen.fetch(fmt.Sprintf("/%d/day/%d/input", gen.year, gen.day))
 	if err != nil {
-		return fmt.Errorf("preparing GET request to %q: %w", url, err)
+		return err
 	}
 
-	req.AddCookie(&http.Cookie{Name: "session", Value: gen.cookie})
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating directory %q: %w", filepath.Dir(path), err)
+	}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	err = ioutil.WriteFile(path, input, 0644)
 	if err != nil {
-		return fmt.Errorf("sending GET request to %q: %w", url, err)
+		return fmt.Errorf("writing input to file %q: %w", path, err)
+	}
+
+	fmt.Printf("  👉 Downloaded input.\n")
+
+	return nil
+}
+
+// DownloadPuzzle fetches the page of the Advent of Code's daily puzzle, for
+// its description and examples. The second part of the puzzle is only included
+// once unlocked, which requires a session cookie.
+func (gen *Generator) DownloadPuzzle() error {
+	path := filepath.Join(gen.packageDir, "README.md")
+	if fileExists(path) && !gen.overwrite && !gen.refresh {
+		fmt.Println("  👉 Skipping puzzle download; description already exists.")
+		return nil
 	}
-	defer resp.Body.Close()
 
-	input, err := ioutil.ReadAll(resp.Body)
+	page, err := gen.fetch(fmt.Sprintf("/%d/day/%d", gen.year, gen.day))
 	if err != nil {
-		return fmt.Errorf("reading response from %q: %w", url, err)
+		return err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("adventofcode.com responded with %d: %s", resp.StatusCode, input)
+	gen.puzzlePage = string(page)
+	gen.examples = extractExamples(gen.puzzlePage)
+
+	fmt.Printf("  👉 Downloaded puzzle.\n")
+
+	return nil
+}
+
+// WriteDescription writes the description of the downloaded puzzle to a
+// README.md file, in Markdown.
+func (gen *Generator) WriteDescription() error {
+	if gen.puzzlePage == "" {
+		return nil
 	}
 
-	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
-		return fmt.Errorf("creating directory %q: %w", filepath.Dir(path), err)
+	path := filepath.Join(gen.packageDir, "README.md")
+
+	description, err := descriptionToMarkdown(gen.puzzlePage, gen.client.BaseURL())
+	if err != nil {
+		return fmt.Errorf("converting description to Markdown: %w", err)
 	}
 
-	err = ioutil.WriteFile(path, input, 0644)
+	source := fmt.Sprintf("%s/%d/day/%d", gen.client.BaseURL(), gen.year, gen.day)
+	readme := fmt.Sprintf("<!-- Downloaded from %s -->\n\n%s", source, description)
+
+	err = ioutil.WriteFile(path, []byte(readme), 0644)
 	if err != nil {
-		return fmt.Errorf("writing input to file %q: %w", path, err)
+		return fmt.Errorf("writing description to file %q: %w", path, err)
 	}
 
-	fmt.Printf("  👉 Downloaded input.\n")
+	if len(puzzleArticles(gen.puzzlePage)) > 1 {
+		fmt.Printf("  👉 Wrote description of both parts.\n")
+	} else {
+		fmt.Printf("  👉 Wrote description of part one.\n")
+	}
 
 	return nil
 }
 
 func (gen *Generator) setModulePath() error {
-	gomodPath := filepath.Join(gen.workdir, "go.mod")
+	modulePath, err := readModulePath(gen.workdir)
+	if err != nil {
+		return err
+	}
+
+	gen.modulePath = modulePath
+	return nil
+}
+
+// readModulePath returns the module path declared in workdir's go.mod file.
+func readModulePath(workdir string) (string, error) {
+	gomodPath := filepath.Join(workdir, "go.mod")
 	gomod, err := ioutil.ReadFile(gomodPath)
 	if err != nil {
-		return fmt.Errorf("reading go.mod: %w", err)
+		return "", fmt.Errorf("reading go.mod: %w", err)
 	}
 
 	modulePath := modfile.ModulePath(gomod)
 	if modulePath == "" {
-		return errors.New("no path in go.mod")
+		return "", errors.New("no path in go.mod")
 	}
 
-	gen.modulePath = modulePath
-	return nil
+	return modulePath, nil
 }
 
 func (gen *Generator) setPackageDir() {
@@ -200,7 +286,7 @@ func (gen *Generator) setPackageDir() {
 func (gen *Generator) renderTemplateIntoFile(templateText, filename string) error {
 	path := filepath.Join(gen.packageDir, filename)
 
-	if fileExists(path) && !gen.overwrite {
+	if fileExists(path) && !gen.overwrite && !gen.unchanged(filename) {
 		fmt.Printf("  👉 Skipping existing file %s.\n", filename)
 		return nil
 	}
@@ -210,31 +296,59 @@ func (gen *Generator) renderTemplateIntoFile(templateText, filename string) erro
 		return fmt.Errorf("parsing template: %w", err)
 	}
 
-	f, err := os.Create(path)
-	if err != nil {
-		return fmt.Errorf("creating or opening file %q: %w", path, err)
-	}
-	defer f.Close()
-
 	data := struct {
-		Day, Year   int
-		PackageName string
+		Day, Year         int
+		PackageName       string
+		AnswerPlaceholder string
+		// Names of the examples for each part of the puzzle.
+		PartOneExamples, PartTwoExamples []string
 	}{
-		Day:         gen.day,
-		Year:        gen.year,
-		PackageName: gen.author,
+		Day:               gen.day,
+		Year:              gen.year,
+		PackageName:       gen.author,
+		AnswerPlaceholder: answerPlaceholder,
+		PartOneExamples:   examplesForPart(gen.examples, 1),
+		PartTwoExamples:   examplesForPart(gen.examples, 2),
 	}
 
-	err = tmpl.Execute(f, data)
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, data)
 	if err != nil {
 		return fmt.Errorf("rendering template: %w", err)
 	}
 
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("formatting code: %w", err)
+	}
+
+	err = ioutil.WriteFile(path, code, 0644)
+	if err != nil {
+		return fmt.Errorf("writing file %q: %w", path, err)
+	}
+	gen.written[filename] = code
+
 	fmt.Printf("  👉 Scaffolded %s.\n", filename)
 
 	return nil
 }
 
+// unchanged checks whether filename was written by gen and has not been
+// edited since.
+func (gen *Generator) unchanged(filename string) bool {
+	written, ok := gen.written[filename]
+	if !ok {
+		return false
+	}
+
+	current, err := ioutil.ReadFile(filepath.Join(gen.packageDir, filename))
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(written, current)
+}
+
 // fileExists checks whether filename exists.
 func fileExists(filename string) bool {
 	info, err := os.Stat(filename)
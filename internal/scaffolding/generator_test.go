@@ -0,0 +1,59 @@
+package scaffolding
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/fabienzucchet/adventofcode/internal/aocclient"
+)
+
+func TestRunWithoutPuzzle(t *testing.T) {
+	// The puzzle page cannot be downloaded, but the input can.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/2022/day/1/input" {
+			fmt.Fprint(w, "1000\n2000\n")
+			return
+		}
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client, err := aocclient.New(aocclient.Config{
+		BaseURL:  server.URL,
+		Cookie:   "secret",
+		Interval: -1,
+		CacheDir: t.TempDir(),
+	})
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+
+	workdir := t.TempDir()
+	if err := ioutil.WriteFile(filepath.Join(workdir, "go.mod"), []byte("module example.com/aoc\n"), 0644); err != nil {
+		t.Fatalf("could not write go.mod: %v", err)
+	}
+
+	gen, err := NewGenerator(1, 2022, "bob", workdir, client, false, false, false)
+	if err != nil {
+		t.Fatalf("could not create generator: %v", err)
+	}
+
+	if err := gen.Run(); err != nil {
+		t.Fatalf("could not run generator: %v", err)
+	}
+
+	for _, name := range []string{"solution.go", "solution_test.go", "testdata/input.txt"} {
+		if !fileExists(filepath.Join(gen.packageDir, name)) {
+			t.Errorf("expected %s to be scaffolded", name)
+		}
+	}
+	for _, name := range []string{"README.md", "testdata/example-1.txt"} {
+		if fileExists(filepath.Join(gen.packageDir, name)) {
+			t.Errorf("expected %s not to be scaffolded without the puzzle", name)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package scaffolding
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	// Puzzle descriptions, one per part of the puzzle.
+	articleRegexp = regexp.MustCompile(`(?s)<article class="day-desc">(.*?)</article>`)
+	// HTML tags, with their name and attributes.
+	tagRegexp  = regexp.MustCompile(`<(/?)([a-zA-Z0-9]+)([^>]*)>`)
+	hrefRegexp = regexp.MustCompile(`href="([^"]*)"`)
+	// Inline code that is entirely emphasized, as is usual for answers.
+	emphasizedCodeRegexp = regexp.MustCompile(`<code><em>([^<]*)</em></code>`)
+	spacesRegexp         = regexp.MustCompile(`[ \t\r\n]+`)
+	blankLinesRegexp     = regexp.MustCompile(`\n{3,}`)
+	markdownEscaper      = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `_`, `\_`, "`", "\\`")
+)
+
+// puzzleArticles returns the HTML contents of each part's description in a
+// puzzle's page.
+func puzzleArticles(page string) []string {
+	var articles []string
+	for _, match := range articleRegexp.FindAllStringSubmatch(page, -1) {
+		articles = append(articles, match[1])
+	}
+	return articles
+}
+
+// descriptionToMarkdown converts the description of a puzzle, as found on the
+// puzzle's page on adventofcode.com, to Markdown. Relative links are resolved
+// against baseURL.
+func descriptionToMarkdown(page, baseURL string) (string, error) {
+	articles := puzzleArticles(page)
+	if len(articles) == 0 {
+		return "", fmt.Errorf("no puzzle description in page")
+	}
+
+	var md strings.Builder
+	for _, article := range articles {
+		md.WriteString(articleToMarkdown(article, baseURL))
+	}
+
+	return strings.TrimSpace(blankLinesRegexp.ReplaceAllString(md.String(), "\n\n")) + "\n", nil
+}
+
+// articleToMarkdown converts one of the articles of a puzzle's description to
+// Markdown. Only the handful of tags used on adventofcode.com are supported;
+// other tags are dropped and their text is kept.
+func articleToMarkdown(article, baseURL string) string {
+	article = emphasizedCodeRegexp.ReplaceAllString(article, "<em><code>$1</code></em>")
+
+	var (
+		md strings.Builder
+		// Whether the converter is inside a <pre> or a <code> element.
+		inPre, inCode bool
+		// Targets of the links being converted.
+		links []string
+	)
+
+	text := func(s string) {
+		s = html.UnescapeString(s)
+		switch {
+		case inPre:
+			md.WriteString(s)
+		case inCode:
+			md.WriteString(spacesRegexp.ReplaceAllString(s, " "))
+		default:
+			md.WriteString(markdownEscaper.Replace(spacesRegexp.ReplaceAllString(s, " ")))
+		}
+	}
+
+	last := 0
+	for _, loc := range tagRegexp.FindAllStringSubmatchIndex(article, -1) {
+		text(article[last:loc[0]])
+		last = loc[1]
+
+		closing := article[loc[2]:loc[3]] == "/"
+		name := strings.ToLower(article[loc[4]:loc[5]])
+		attrs := article[loc[6]:loc[7]]
+
+		switch {
+		case name == "h2" && !closing:
+			md.WriteString("\n\n## ")
+		case name == "h2":
+			md.WriteString("\n\n")
+		case name == "p":
+			md.WriteString("\n\n")
+		case name == "pre" && !closing:
+			inPre = true
+			md.WriteString("\n\n```\n")
+		case name == "pre":
+			inPre = false
+			if !strings.HasSuffix(md.String(), "\n") {
+				md.WriteString("\n")
+			}
+			md.WriteString("```\n\n")
+		case name == "code" && !inPre:
+			inCode = !closing
+			md.WriteString("`")
+		case name == "em" && !inPre && !inCode:
+			md.WriteString("**")
+		case name == "ul":
+			md.WriteString("\n\n")
+		case name == "li" && !closing:
+			md.WriteString("\n- ")
+		case name == "a" && !closing:
+			href := ""
+			if m := hrefRegexp.FindStringSubmatch(attrs); m != nil {
+				href = html.UnescapeString(m[1])
+			}
+			if strings.HasPrefix(href, "/") {
+				href = baseURL + href
+			}
+			links = append(links, href)
+			md.WriteString("[")
+		case name == "a" && len(links) > 0:
+			md.WriteString("](" + links[len(links)-1] + ")")
+			links = links[:len(links)-1]
+		}
+	}
+	text(article[last:])
+
+	// Clean up lines outside of code blocks. Headers look like
+	// "--- Day 1: Title ---" on adventofcode.com.
+	lines := strings.Split(md.String(), "\n")
+	inBlock := false
+	for i, line := range lines {
+		switch {
+		case line == "```":
+			inBlock = !inBlock
+		case inBlock:
+		case strings.HasPrefix(line, "## "):
+			lines[i] = "## " + strings.Trim(strings.TrimPrefix(line, "## "), " -")
+		default:
+			lines[i] = strings.TrimSpace(line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
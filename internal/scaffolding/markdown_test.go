@@ -0,0 +1,62 @@
+package scaffolding
+
+import "testing"
+
+const puzzlePage = `<!DOCTYPE html>
+<html lang="en-us">
+<body>
+<main>
+<article class="day-desc"><h2>--- Day 1: Calorie Counting ---</h2><p>The Elves take turns writing down the number of <em>Calories</em> contained by the various meals.</p>
+<p>For example, suppose the Elves finally get their inventory &amp; it looks like this:</p>
+<pre><code>1000
+2000
+
+<em>3000</em>
+</code></pre>
+<ul>
+<li>The first Elf is carrying food with <code>1000</code> and <code>2000</code> Calories.</li>
+<li>See <a href="/2022/day/1/input" target="_blank">your input</a>.</li>
+</ul>
+<p>Find the Elf carrying the most Calories. <em>How many total Calories is that Elf carrying?</em> In the example above, this is <code><em>24000</em></code> (<code>4*6000</code>).</p>
+</article>
+<p>Your puzzle answer was <code>69289</code>.</p><article class="day-desc"><h2 id="part2">--- Part Two ---</h2><p>Find the top three Elves carrying the most Calories.</p>
+</article>
+</main>
+</body>
+</html>`
+
+const puzzleMarkdown = "## Day 1: Calorie Counting\n" +
+	"\n" +
+	"The Elves take turns writing down the number of **Calories** contained by the various meals.\n" +
+	"\n" +
+	"For example, suppose the Elves finally get their inventory & it looks like this:\n" +
+	"\n" +
+	"```\n" +
+	"1000\n" +
+	"2000\n" +
+	"\n" +
+	"3000\n" +
+	"```\n" +
+	"\n" +
+	"- The first Elf is carrying food with `1000` and `2000` Calories.\n" +
+	"- See [your input](https://adventofcode.com/2022/day/1/input).\n" +
+	"\n" +
+	"Find the Elf carrying the most Calories. **How many total Calories is that Elf carrying?** In the example above, this is **`24000`** (`4*6000`).\n" +
+	"\n" +
+	"## Part Two\n" +
+	"\n" +
+	"Find the top three Elves carrying the most Calories.\n"
+
+func TestDescriptionToMarkdown(t *testing.T) {
+	md, err := descriptionToMarkdown(puzzlePage, "https://adventofcode.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if md != puzzleMarkdown {
+		t.Errorf("unexpected Markdown:\n%s\nexpected:\n%s", md, puzzleMarkdown)
+	}
+
+	if _, err := descriptionToMarkdown("<html></html>", "https://adventofcode.com"); err == nil {
+		t.Error("expected error for page without description")
+	}
+}
@@ -0,0 +1,135 @@
+package scaffolding
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// stubComment is the comment left in the body of each part's function by the
+// solution template, until the part is solved.
+const stubComment = "TODO: Write code to solve Part %d here."
+
+// A PartState tells how far along the solution to one part of a puzzle is.
+type PartState int
+
+const (
+	// The solution has no function for the part.
+	PartMissing PartState = iota
+	// The function for the part is still the one from the solution template.
+	PartStub
+	// The function for the part was written, but its example test still
+	// expects the answer placeholder.
+	PartUnverified
+	// The function for the part was written, and its example test expects an
+	// actual answer.
+	PartSolved
+)
+
+var partStateNames = map[PartState]string{
+	PartMissing:    "missing",
+	PartStub:       "stub",
+	PartUnverified: "unverified",
+	PartSolved:     "solved",
+}
+
+func (s PartState) String() string {
+	if name, ok := partStateNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("PartState(%d)", int(s))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s PartState) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *PartState) UnmarshalText(text []byte) error {
+	for state, name := range partStateNames {
+		if name == string(text) {
+			*s = state
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown part state %q", text)
+}
+
+// InspectPart tells how far along the solution to part of the puzzle solved in
+// packageDir is, by comparing the solution and its tests to the templates they
+// were scaffolded from.
+func InspectPart(packageDir string, part int) (PartState, error) {
+	name, ok := partNames[part]
+	if !ok {
+		return PartMissing, fmt.Errorf("invalid part: %d", part)
+	}
+
+	src, err := ioutil.ReadFile(filepath.Join(packageDir, "solution.go"))
+	if os.IsNotExist(err) {
+		return PartMissing, nil
+	}
+	if err != nil {
+		return PartMissing, fmt.Errorf("reading solution: %w", err)
+	}
+
+	stub, found, err := isStub(src, "Part"+name, fmt.Sprintf(stubComment, part))
+	if err != nil {
+		return PartMissing, fmt.Errorf("inspecting solution: %w", err)
+	}
+	if !found {
+		return PartMissing, nil
+	}
+	if stub {
+		return PartStub, nil
+	}
+
+	// Solutions without example tests are trusted to be solved.
+	tests, err := ioutil.ReadFile(filepath.Join(packageDir, "solution_test.go"))
+	if os.IsNotExist(err) {
+		return PartSolved, nil
+	}
+	if err != nil {
+		return PartMissing, fmt.Errorf("reading tests: %w", err)
+	}
+
+	_, _, output, err := findExampleOutput(tests, "ExamplePart"+name)
+	if err == nil && output == answerPlaceholder {
+		return PartUnverified, nil
+	}
+
+	return PartSolved, nil
+}
+
+// isStub reports whether the body of the function funcName in src holds the
+// comment left by the solution template. found is false if src declares no such
+// function.
+func isStub(src []byte, funcName, comment string) (stub, found bool, err error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return false, false, fmt.Errorf("parsing source: %w", err)
+	}
+
+	fn := findFunc(f, funcName)
+	if fn == nil || fn.Body == nil {
+		return false, false, nil
+	}
+
+	for _, cg := range f.Comments {
+		if cg.Pos() < fn.Body.Lbrace || cg.End() > fn.Body.Rbrace {
+			continue
+		}
+		for _, c := range cg.List {
+			if strings.HasPrefix(commentText(c), comment) {
+				return true, true, nil
+			}
+		}
+	}
+
+	return false, true, nil
+}
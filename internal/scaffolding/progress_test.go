@@ -0,0 +1,48 @@
+package scaffolding
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInspectPart(t *testing.T) {
+	gen := &Generator{day: 1, year: 2022, author: "bob", packageDir: t.TempDir(), written: make(map[string][]byte)}
+
+	expect := func(step string, partOne, partTwo PartState) {
+		t.Helper()
+		for part, expected := range map[int]PartState{1: partOne, 2: partTwo} {
+			state, err := InspectPart(gen.packageDir, part)
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", step, err)
+			}
+			if state != expected {
+				t.Errorf("%s: expected part %d to be %s, got %s", step, part, expected, state)
+			}
+		}
+	}
+
+	expect("before scaffolding", PartMissing, PartMissing)
+
+	if err := gen.WriteCode(); err != nil {
+		t.Fatalf("could not write code: %v", err)
+	}
+	expect("after scaffolding", PartStub, PartStub)
+
+	path := filepath.Join(gen.packageDir, "solution.go")
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("could not read solution: %v", err)
+	}
+	src = []byte(strings.Replace(string(src), "// TODO: Write code to solve Part 1 here.", "// Count lines.", 1))
+	if err := ioutil.WriteFile(path, src, 0644); err != nil {
+		t.Fatalf("could not write solution: %v", err)
+	}
+	expect("after solving part one", PartUnverified, PartStub)
+
+	if err := AcceptAnswer(gen.packageDir, 1, "42", false); err != nil {
+		t.Fatalf("could not accept answer: %v", err)
+	}
+	expect("after accepting part one", PartSolved, PartStub)
+}
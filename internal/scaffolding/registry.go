@@ -0,0 +1,151 @@
+package scaffolding
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"text/template"
+)
+
+// registryPath is the location of the generated registry, relative to the
+// working directory.
+var registryPath = filepath.Join("internal", "solutions", "registry.go")
+
+// A registryEntry describes a solution package found in the working directory.
+type registryEntry struct {
+	Year, Day  int
+	Author     string
+	ImportPath string
+	// Name used to import the package in the registry.
+	Alias string
+	// Whether the package declares PartOne and PartTwo functions.
+	HasPartOne, HasPartTwo bool
+}
+
+// WriteRegistry scans workdir for solution packages and writes the registry
+// the adventofcode CLI uses to find solutions.
+func WriteRegistry(workdir string) error {
+	modulePath, err := readModulePath(workdir)
+	if err != nil {
+		return fmt.Errorf("unknown module path: %w", err)
+	}
+
+	return writeRegistry(workdir, modulePath)
+}
+
+// UpdateRegistry regenerates the registry of solutions so that it includes
+// the scaffolded package.
+func (gen *Generator) UpdateRegistry() error {
+	if _, err := os.Stat(filepath.Dir(filepath.Join(gen.workdir, registryPath))); os.IsNotExist(err) {
+		fmt.Println("  👉 Skipping registry update; no registry in working directory.")
+		return nil
+	}
+
+	if err := writeRegistry(gen.workdir, gen.modulePath); err != nil {
+		return err
+	}
+
+	fmt.Println("  👉 Updated solution registry.")
+	return nil
+}
+
+func writeRegistry(workdir, modulePath string) error {
+	entries, err := findSolutions(workdir, modulePath)
+	if err != nil {
+		return fmt.Errorf("finding solutions: %w", err)
+	}
+
+	tmpl, err := template.New("registry").Parse(registryTemplate)
+	if err != nil {
+		return fmt.Errorf("parsing template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, entries); err != nil {
+		return fmt.Errorf("rendering template: %w", err)
+	}
+
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("formatting registry: %w", err)
+	}
+
+	path := filepath.Join(workdir, registryPath)
+	if err := ioutil.WriteFile(path, code, 0644); err != nil {
+		return fmt.Errorf("writing registry to file %q: %w", path, err)
+	}
+
+	return nil
+}
+
+// findSolutions lists all solution packages in workdir, sorted by year, day
+// and author.
+func findSolutions(workdir, modulePath string) ([]registryEntry, error) {
+	pattern := filepath.Join(workdir, "y[0-9][0-9][0-9][0-9]", "d[0-9][0-9]", "*", "solution.go")
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("listing solutions: %w", err)
+	}
+
+	var entries []registryEntry
+	for _, path := range paths {
+		authorDir := filepath.Dir(path)
+		dayDir := filepath.Dir(authorDir)
+		yearDir := filepath.Dir(dayDir)
+
+		author := filepath.Base(authorDir)
+		if !validAuthorRegexp.MatchString(author) {
+			continue
+		}
+		day, err := strconv.Atoi(filepath.Base(dayDir)[1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid day directory %q: %w", dayDir, err)
+		}
+		year, err := strconv.Atoi(filepath.Base(yearDir)[1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid year directory %q: %w", yearDir, err)
+		}
+
+		funcs, err := declaredFuncs(path)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, registryEntry{
+			Year:       year,
+			Day:        day,
+			Author:     author,
+			ImportPath: fmt.Sprintf("%s/y%04d/d%02d/%s", modulePath, year, day, author),
+			Alias:      fmt.Sprintf("y%04dd%02d%s", year, day, author),
+			HasPartOne: funcs["PartOne"],
+			HasPartTwo: funcs["PartTwo"],
+		})
+	}
+
+	return entries, nil
+}
+
+// declaredFuncs returns the names of the top-level functions declared in the
+// Go file at path.
+func declaredFuncs(path string) (map[string]bool, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q: %w", path, err)
+	}
+
+	funcs := make(map[string]bool)
+	for _, decl := range f.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil {
+			funcs[fn.Name.Name] = true
+		}
+	}
+
+	return funcs, nil
+}
@@ -0,0 +1,167 @@
+package scaffolding
+
+import (
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeFiles writes files, by path relative to dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("could not create directory: %v", err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("could not write %s: %v", name, err)
+		}
+	}
+}
+
+func TestFindSolutions(t *testing.T) {
+	tests := map[string]struct {
+		files   map[string]string
+		entries []registryEntry
+		err     bool
+	}{
+		"both parts": {
+			files: map[string]string{
+				"y2022/d01/bob/solution.go": "package bob\nfunc PartOne() {}\nfunc PartTwo() {}\n",
+			},
+			entries: []registryEntry{
+				{Year: 2022, Day: 1, Author: "bob", ImportPath: "example.com/aoc/y2022/d01/bob", Alias: "y2022d01bob", HasPartOne: true, HasPartTwo: true},
+			},
+		},
+		"only part one": {
+			files: map[string]string{
+				"y2020/d25/bob/solution.go": "package bob\nfunc PartOne() {}\ntype t struct{}\nfunc (t) PartTwo() {}\n",
+			},
+			entries: []registryEntry{
+				{Year: 2020, Day: 25, Author: "bob", ImportPath: "example.com/aoc/y2020/d25/bob", Alias: "y2020d25bob", HasPartOne: true},
+			},
+		},
+		"sorted": {
+			files: map[string]string{
+				"y2022/d02/bob/solution.go":   "package bob\n",
+				"y2022/d01/bob/solution.go":   "package bob\n",
+				"y2021/d10/alice/solution.go": "package alice\n",
+				"y2022/d01/alice/solution.go": "package alice\n",
+			},
+			entries: []registryEntry{
+				{Year: 2021, Day: 10, Author: "alice", ImportPath: "example.com/aoc/y2021/d10/alice", Alias: "y2021d10alice"},
+				{Year: 2022, Day: 1, Author: "alice", ImportPath: "example.com/aoc/y2022/d01/alice", Alias: "y2022d01alice"},
+				{Year: 2022, Day: 1, Author: "bob", ImportPath: "example.com/aoc/y2022/d01/bob", Alias: "y2022d01bob"},
+				{Year: 2022, Day: 2, Author: "bob", ImportPath: "example.com/aoc/y2022/d02/bob", Alias: "y2022d02bob"},
+			},
+		},
+		"invalid authors": {
+			files: map[string]string{
+				"y2022/d01/fabien-z/solution.go": "package fabienz\nfunc PartOne() {}\n",
+				"y2022/d01/Bob/solution.go":      "package bob\nfunc PartOne() {}\n",
+				"y2022/d01/bob2/solution.go":     "package bob2\nfunc PartOne() {}\n",
+			},
+		},
+		"not a solution": {
+			files: map[string]string{
+				"y2022/d01/bob/README.md": "# Day 1\n",
+				"y22/d01/bob/solution.go": "package bob\n",
+			},
+		},
+		"invalid code": {
+			files: map[string]string{
+				"y2022/d01/bob/solution.go": "package bob\nfunc PartOne( {}\n",
+			},
+			err: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			workdir := t.TempDir()
+			writeFiles(t, workdir, test.files)
+
+			entries, err := findSolutions(workdir, "example.com/aoc")
+			if test.err {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(entries, test.entries) {
+				t.Errorf("expected entries %+v, got %+v", test.entries, entries)
+			}
+		})
+	}
+}
+
+func TestDeclaredFuncs(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"solution.go": "package bob\nfunc PartOne() {}\nfunc helper() {}\ntype t struct{}\nfunc (t) PartTwo() {}\n",
+	})
+
+	funcs, err := declaredFuncs(filepath.Join(dir, "solution.go"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := map[string]bool{"PartOne": true, "helper": true}; !reflect.DeepEqual(funcs, expected) {
+		t.Errorf("expected functions %v, got %v", expected, funcs)
+	}
+
+	if _, err := declaredFuncs(filepath.Join(dir, "missing.go")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestWriteRegistry(t *testing.T) {
+	workdir := t.TempDir()
+	writeFiles(t, workdir, map[string]string{
+		"go.mod":                         "module example.com/aoc\n",
+		"internal/solutions/registry.go": "package solutions\n",
+		"y2022/d01/bob/solution.go":      "package bob\nfunc PartOne() {}\nfunc PartTwo() {}\n",
+		"y2022/d02/bob/solution.go":      "package bob\nfunc PartOne() {}\n",
+		"y2022/d01/fabien-z/solution.go": "package fabienz\nfunc PartOne() {}\n",
+	})
+
+	if err := WriteRegistry(workdir); err != nil {
+		t.Fatalf("could not write registry: %v", err)
+	}
+
+	path := filepath.Join(workdir, registryPath)
+	code, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("could not read registry: %v", err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), path, code, 0); err != nil {
+		t.Fatalf("registry is not valid Go: %v", err)
+	}
+
+	for _, line := range []string{
+		`y2022d01bob "example.com/aoc/y2022/d01/bob"`,
+		`register(2022, 1, "bob", y2022d01bob.PartOne, y2022d01bob.PartTwo)`,
+		`register(2022, 2, "bob", y2022d02bob.PartOne, nil)`,
+	} {
+		if !strings.Contains(string(code), line) {
+			t.Errorf("expected registry to contain %q, got:\n%s", line, code)
+		}
+	}
+	if strings.Contains(string(code), "fabien-z") {
+		t.Errorf("expected registry to skip invalid authors, got:\n%s", code)
+	}
+}
+
+func TestWriteRegistryWithoutModule(t *testing.T) {
+	if err := WriteRegistry(t.TempDir()); err == nil {
+		t.Error("expected an error without a go.mod file")
+	}
+}
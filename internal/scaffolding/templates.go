@@ -1,5 +1,9 @@
 package scaffolding
 
+// answerPlaceholder stands for the answer in scaffolded tests, until the
+// answer is known.
+const answerPlaceholder = "👉 Write the answer here 👈"
+
 var solutionTemplate = `package {{ .PackageName }}
 
 import (
@@ -68,7 +72,7 @@ func ExamplePartOne() {
 	if err := PartOne(file, os.Stdout); err != nil {
 		log.Fatalf("could not solve: %v", err)
 	}
-	// Output: 👉 Write the answer here 👈
+	// Output: {{ .AnswerPlaceholder }}
 }
 
 func ExamplePartTwo() {
@@ -81,7 +85,47 @@ func ExamplePartTwo() {
 	if err := PartTwo(file, os.Stdout); err != nil {
 		log.Fatalf("could not solve: %v", err)
 	}
-	// Output: 👉 Write the answer here 👈
+	// Output: {{ .AnswerPlaceholder }}
+}
+
+func TestPartOne(t *testing.T) {
+	testCases := map[string]struct {
+		inputFile  string
+		answerFile string
+	}{
+{{- range .PartOneExamples }}
+		"{{ . }}": {
+			inputFile:  "testdata/{{ . }}.txt",
+			answerFile: "testdata/{{ . }}-part-one-answer.txt",
+		},
+{{- end }}
+	}
+
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			helpers.TestSolution(t, helpers.SolutionFunc(PartOne), test.inputFile, test.answerFile)
+		})
+	}
+}
+
+func TestPartTwo(t *testing.T) {
+	testCases := map[string]struct {
+		inputFile  string
+		answerFile string
+	}{
+{{- range .PartTwoExamples }}
+		"{{ . }}": {
+			inputFile:  "testdata/{{ . }}.txt",
+			answerFile: "testdata/{{ . }}-part-two-answer.txt",
+		},
+{{- end }}
+	}
+
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			helpers.TestSolution(t, helpers.SolutionFunc(PartTwo), test.inputFile, test.answerFile)
+		})
+	}
 }
 
 func Benchmark(b *testing.B) {
@@ -107,3 +151,20 @@ func Benchmark(b *testing.B) {
 	}
 }
 `
+
+var registryTemplate = `// Code generated by "go generate ./internal/solutions"; DO NOT EDIT.
+
+package solutions
+
+import (
+{{- range . }}
+	{{ .Alias }} "{{ .ImportPath }}"
+{{- end }}
+)
+
+func init() {
+{{- range . }}
+	register({{ .Year }}, {{ .Day }}, "{{ .Author }}", {{ if .HasPartOne }}{{ .Alias }}.PartOne{{ else }}nil{{ end }}, {{ if .HasPartTwo }}{{ .Alias }}.PartTwo{{ else }}nil{{ end }})
+{{- end }}
+}
+`
@@ -0,0 +1,86 @@
+package scaffolding
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fabienzucchet/adventofcode/internal/aocclient"
+)
+
+const (
+	// How long to wait for a puzzle at most.
+	maxWait = 24 * time.Hour
+	// How long to keep polling for an unlocked puzzle.
+	pollTimeout = 10 * time.Minute
+	// Bounds of the delay between two polls.
+	minPollDelay = time.Second
+	maxPollDelay = 30 * time.Second
+)
+
+// Puzzles are unlocked at midnight in America/New_York, which is always UTC-5
+// in December.
+var unlockZone = time.FixedZone("EST", -5*60*60)
+
+// UnlockTime returns the instant the puzzle of the given day and year is
+// unlocked.
+func UnlockTime(year, day int) time.Time {
+	return time.Date(year, time.December, day, 0, 0, 0, 0, unlockZone)
+}
+
+// WaitForUnlock scaffolds code right away, so you can get started, then waits
+// until the puzzle is unlocked while showing a countdown.
+func (gen *Generator) WaitForUnlock() error {
+	unlock := UnlockTime(gen.year, gen.day)
+
+	left := unlock.Sub(gen.client.Now())
+	if left > maxWait {
+		return fmt.Errorf("puzzle unlocks in %s, on %s", left.Round(time.Minute), unlock.Local().Format(time.RFC1123))
+	}
+	if left <= 0 {
+		return nil
+	}
+
+	if err := gen.WriteCode(); err != nil {
+		return fmt.Errorf("writing code: %w", err)
+	}
+
+	for left > 0 {
+		fmt.Printf("\r  ⏰ Puzzle unlocks in %s ", left.Round(time.Second))
+
+		// Sleep until the countdown's next round second.
+		step := left % time.Second
+		if step == 0 {
+			step = time.Second
+		}
+		gen.client.Sleep(step)
+
+		left = unlock.Sub(gen.client.Now())
+	}
+	fmt.Printf("\r  🔓 Puzzle unlocked!          \n")
+
+	return nil
+}
+
+// fetch downloads the page at path from adventofcode.com. When waiting for
+// the puzzle to be unlocked, fetch tries again with an increasing delay until
+// the page exists.
+func (gen *Generator) fetch(path string) ([]byte, error) {
+	deadline := gen.client.Now().Add(pollTimeout)
+	delay := minPollDelay
+
+	for {
+		body, err := gen.client.Get(path)
+		if !gen.wait || !errors.Is(err, aocclient.ErrNotFound) || gen.client.Now().After(deadline) {
+			return body, err
+		}
+
+		fmt.Printf("  👉 Puzzle not available yet; trying again in %s.\n", delay)
+		gen.client.Sleep(delay)
+
+		delay *= 2
+		if delay > maxPollDelay {
+			delay = maxPollDelay
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package scaffolding
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fabienzucchet/adventofcode/internal/aocclient"
+)
+
+func TestUnlockTime(t *testing.T) {
+	unlock := UnlockTime(2022, 10)
+	expected := time.Date(2022, time.December, 10, 5, 0, 0, 0, time.UTC)
+
+	if !unlock.Equal(expected) {
+		t.Errorf("expected puzzle to unlock at %s, got %s", expected, unlock.UTC())
+	}
+}
+
+func TestRunWait(t *testing.T) {
+	// Pages are not found until they have been requested a few times.
+	requests := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		if requests[r.URL.Path] < 3 {
+			http.Error(w, "Please don't repeatedly request this endpoint before it unlocks!", http.StatusNotFound)
+			return
+		}
+		switch r.URL.Path {
+		case "/2022/day/1":
+			fmt.Fprint(w, puzzlePage)
+		case "/2022/day/1/input":
+			fmt.Fprint(w, "1000\n2000\n")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	unlock := UnlockTime(2022, 1)
+	now := unlock.Add(-90 * time.Second)
+
+	client, err := aocclient.New(aocclient.Config{
+		BaseURL:  server.URL,
+		Cookie:   "secret",
+		Interval: -1,
+		CacheDir: t.TempDir(),
+		Now:      func() time.Time { return now },
+		Sleep:    func(d time.Duration) { now = now.Add(d) },
+	})
+	if err != nil {
+		t.Fatalf("could not create client: %v", err)
+	}
+
+	workdir := t.TempDir()
+	if err := ioutil.WriteFile(filepath.Join(workdir, "go.mod"), []byte("module example.com/aoc\n"), 0644); err != nil {
+		t.Fatalf("could not write go.mod: %v", err)
+	}
+
+	gen, err := NewGenerator(1, 2022, "bob", workdir, client, false, false, true)
+	if err != nil {
+		t.Fatalf("could not create generator: %v", err)
+	}
+
+	if err := gen.Run(); err != nil {
+		t.Fatalf("could not run generator: %v", err)
+	}
+
+	if now.Before(unlock) {
+		t.Errorf("expected to wait until %s, stopped at %s", unlock, now)
+	}
+
+	for _, name := range []string{"solution.go", "README.md", "testdata/input.txt", "testdata/example-1.txt"} {
+		if !fileExists(filepath.Join(gen.packageDir, name)) {
+			t.Errorf("expected %s to be scaffolded", name)
+		}
+	}
+
+	tests, err := ioutil.ReadFile(filepath.Join(gen.packageDir, "solution_test.go"))
+	if err != nil {
+		t.Fatalf("could not read tests: %v", err)
+	}
+	if !strings.Contains(string(tests), "testdata/example-1.txt") {
+		t.Error("expected tests to be updated with examples once unlocked")
+	}
+}
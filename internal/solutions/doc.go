@@ -0,0 +1,10 @@
+// Package solutions maps Advent of Code puzzles to the solutions found in this
+// repository.
+//
+// The registry is generated from the yYYYY/dDD/<author> packages. It is
+// updated every time a package is scaffolded, and can be regenerated with:
+//
+//	go generate ./internal/solutions
+package solutions
+
+//go:generate go run ./gen -workdir ../..
@@ -0,0 +1,21 @@
+// Command gen writes the registry of solutions found in the Advent of Code
+// working directory.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/fabienzucchet/adventofcode/internal/scaffolding"
+)
+
+func main() {
+	workdir := flag.String("workdir", ".", "Your Advent of Code working directory")
+	flag.Parse()
+
+	if err := scaffolding.WriteRegistry(*workdir); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
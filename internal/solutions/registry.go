@@ -0,0 +1,193 @@
+// Code generated by "go generate ./internal/solutions"; DO NOT EDIT.
+
+package solutions
+
+import (
+	y2019d01fabienz "github.com/fabienzucchet/adventofcode/y2019/d01/fabienz"
+	y2019d02fabienz "github.com/fabienzucchet/adventofcode/y2019/d02/fabienz"
+	y2019d03fabienz "github.com/fabienzucchet/adventofcode/y2019/d03/fabienz"
+	y2019d04fabienz "github.com/fabienzucchet/adventofcode/y2019/d04/fabienz"
+	y2019d05fabienz "github.com/fabienzucchet/adventofcode/y2019/d05/fabienz"
+	y2019d06fabienz "github.com/fabienzucchet/adventofcode/y2019/d06/fabienz"
+	y2019d07fabienz "github.com/fabienzucchet/adventofcode/y2019/d07/fabienz"
+	y2019d08fabienz "github.com/fabienzucchet/adventofcode/y2019/d08/fabienz"
+	y2019d09fabienz "github.com/fabienzucchet/adventofcode/y2019/d09/fabienz"
+	y2019d10fabienz "github.com/fabienzucchet/adventofcode/y2019/d10/fabienz"
+	y2019d11fabienz "github.com/fabienzucchet/adventofcode/y2019/d11/fabienz"
+	y2019d12fabienz "github.com/fabienzucchet/adventofcode/y2019/d12/fabienz"
+	y2019d13fabienz "github.com/fabienzucchet/adventofcode/y2019/d13/fabienz"
+	y2019d14fabienz "github.com/fabienzucchet/adventofcode/y2019/d14/fabienz"
+	y2020d01fabienz "github.com/fabienzucchet/adventofcode/y2020/d01/fabienz"
+	y2020d02fabienz "github.com/fabienzucchet/adventofcode/y2020/d02/fabienz"
+	y2020d03fabienz "github.com/fabienzucchet/adventofcode/y2020/d03/fabienz"
+	y2020d04fabienz "github.com/fabienzucchet/adventofcode/y2020/d04/fabienz"
+	y2020d05fabienz "github.com/fabienzucchet/adventofcode/y2020/d05/fabienz"
+	y2020d06fabienz "github.com/fabienzucchet/adventofcode/y2020/d06/fabienz"
+	y2020d07fabienz "github.com/fabienzucchet/adventofcode/y2020/d07/fabienz"
+	y2020d08fabienz "github.com/fabienzucchet/adventofcode/y2020/d08/fabienz"
+	y2020d09fabienz "github.com/fabienzucchet/adventofcode/y2020/d09/fabienz"
+	y2020d10fabienz "github.com/fabienzucchet/adventofcode/y2020/d10/fabienz"
+	y2020d11fabienz "github.com/fabienzucchet/adventofcode/y2020/d11/fabienz"
+	y2020d12fabienz "github.com/fabienzucchet/adventofcode/y2020/d12/fabienz"
+	y2020d13fabienz "github.com/fabienzucchet/adventofcode/y2020/d13/fabienz"
+	y2020d14fabienz "github.com/fabienzucchet/adventofcode/y2020/d14/fabienz"
+	y2020d15fabienz "github.com/fabienzucchet/adventofcode/y2020/d15/fabienz"
+	y2020d16fabienz "github.com/fabienzucchet/adventofcode/y2020/d16/fabienz"
+	y2020d17fabienz "github.com/fabienzucchet/adventofcode/y2020/d17/fabienz"
+	y2020d18fabienz "github.com/fabienzucchet/adventofcode/y2020/d18/fabienz"
+	y2020d19fabienz "github.com/fabienzucchet/adventofcode/y2020/d19/fabienz"
+	y2020d20fabienz "github.com/fabienzucchet/adventofcode/y2020/d20/fabienz"
+	y2020d21fabienz "github.com/fabienzucchet/adventofcode/y2020/d21/fabienz"
+	y2020d22fabienz "github.com/fabienzucchet/adventofcode/y2020/d22/fabienz"
+	y2020d23fabienz "github.com/fabienzucchet/adventofcode/y2020/d23/fabienz"
+	y2020d24fabienz "github.com/fabienzucchet/adventofcode/y2020/d24/fabienz"
+	y2020d25fabienz "github.com/fabienzucchet/adventofcode/y2020/d25/fabienz"
+	y2021d01fabienz "github.com/fabienzucchet/adventofcode/y2021/d01/fabienz"
+	y2021d02fabienz "github.com/fabienzucchet/adventofcode/y2021/d02/fabienz"
+	y2021d03fabienz "github.com/fabienzucchet/adventofcode/y2021/d03/fabienz"
+	y2021d04fabienz "github.com/fabienzucchet/adventofcode/y2021/d04/fabienz"
+	y2021d05fabienz "github.com/fabienzucchet/adventofcode/y2021/d05/fabienz"
+	y2021d06fabienz "github.com/fabienzucchet/adventofcode/y2021/d06/fabienz"
+	y2021d07fabienz "github.com/fabienzucchet/adventofcode/y2021/d07/fabienz"
+	y2021d08fabienz "github.com/fabienzucchet/adventofcode/y2021/d08/fabienz"
+	y2021d09fabienz "github.com/fabienzucchet/adventofcode/y2021/d09/fabienz"
+	y2021d10fabienz "github.com/fabienzucchet/adventofcode/y2021/d10/fabienz"
+	y2021d11fabienz "github.com/fabienzucchet/adventofcode/y2021/d11/fabienz"
+	y2021d12fabienz "github.com/fabienzucchet/adventofcode/y2021/d12/fabienz"
+	y2021d13fabienz "github.com/fabienzucchet/adventofcode/y2021/d13/fabienz"
+	y2021d14fabienz "github.com/fabienzucchet/adventofcode/y2021/d14/fabienz"
+	y2021d16fabienz "github.com/fabienzucchet/adventofcode/y2021/d16/fabienz"
+	y2021d17fabienz "github.com/fabienzucchet/adventofcode/y2021/d17/fabienz"
+	y2021d18fabienz "github.com/fabienzucchet/adventofcode/y2021/d18/fabienz"
+	y2021d19fabienz "github.com/fabienzucchet/adventofcode/y2021/d19/fabienz"
+	y2021d20fabienz "github.com/fabienzucchet/adventofcode/y2021/d20/fabienz"
+	y2021d21fabienz "github.com/fabienzucchet/adventofcode/y2021/d21/fabienz"
+	y2021d22fabienz "github.com/fabienzucchet/adventofcode/y2021/d22/fabienz"
+	y2021d25fabienz "github.com/fabienzucchet/adventofcode/y2021/d25/fabienz"
+	y2022d01fabienz "github.com/fabienzucchet/adventofcode/y2022/d01/fabienz"
+	y2022d02fabienz "github.com/fabienzucchet/adventofcode/y2022/d02/fabienz"
+	y2022d03fabienz "github.com/fabienzucchet/adventofcode/y2022/d03/fabienz"
+	y2022d04fabienz "github.com/fabienzucchet/adventofcode/y2022/d04/fabienz"
+	y2022d05fabienz "github.com/fabienzucchet/adventofcode/y2022/d05/fabienz"
+	y2022d06fabienz "github.com/fabienzucchet/adventofcode/y2022/d06/fabienz"
+	y2022d07fabienz "github.com/fabienzucchet/adventofcode/y2022/d07/fabienz"
+	y2022d08fabienz "github.com/fabienzucchet/adventofcode/y2022/d08/fabienz"
+	y2022d09fabienz "github.com/fabienzucchet/adventofcode/y2022/d09/fabienz"
+	y2022d10fabienz "github.com/fabienzucchet/adventofcode/y2022/d10/fabienz"
+	y2022d11fabienz "github.com/fabienzucchet/adventofcode/y2022/d11/fabienz"
+	y2022d12fabienz "github.com/fabienzucchet/adventofcode/y2022/d12/fabienz"
+	y2022d13fabienz "github.com/fabienzucchet/adventofcode/y2022/d13/fabienz"
+	y2022d14fabienz "github.com/fabienzucchet/adventofcode/y2022/d14/fabienz"
+	y2022d15fabienz "github.com/fabienzucchet/adventofcode/y2022/d15/fabienz"
+	y2022d16fabienz "github.com/fabienzucchet/adventofcode/y2022/d16/fabienz"
+	y2022d17fabienz "github.com/fabienzucchet/adventofcode/y2022/d17/fabienz"
+	y2022d18fabienz "github.com/fabienzucchet/adventofcode/y2022/d18/fabienz"
+	y2022d19fabienz "github.com/fabienzucchet/adventofcode/y2022/d19/fabienz"
+	y2022d20fabienz "github.com/fabienzucchet/adventofcode/y2022/d20/fabienz"
+	y2022d21fabienz "github.com/fabienzucchet/adventofcode/y2022/d21/fabienz"
+	y2022d22fabienz "github.com/fabienzucchet/adventofcode/y2022/d22/fabienz"
+	y2022d23fabienz "github.com/fabienzucchet/adventofcode/y2022/d23/fabienz"
+	y2022d24fabienz "github.com/fabienzucchet/adventofcode/y2022/d24/fabienz"
+	y2022d25fabienz "github.com/fabienzucchet/adventofcode/y2022/d25/fabienz"
+	y2024d01fabienz "github.com/fabienzucchet/adventofcode/y2024/d01/fabienz"
+	y2024d02fabienz "github.com/fabienzucchet/adventofcode/y2024/d02/fabienz"
+	y2024d03fabienz "github.com/fabienzucchet/adventofcode/y2024/d03/fabienz"
+	y2024d04fabienz "github.com/fabienzucchet/adventofcode/y2024/d04/fabienz"
+	y2024d05fabienz "github.com/fabienzucchet/adventofcode/y2024/d05/fabienz"
+	y2024d06fabienz "github.com/fabienzucchet/adventofcode/y2024/d06/fabienz"
+)
+
+func init() {
+	register(2019, 1, "fabienz", y2019d01fabienz.PartOne, y2019d01fabienz.PartTwo)
+	register(2019, 2, "fabienz", y2019d02fabienz.PartOne, y2019d02fabienz.PartTwo)
+	register(2019, 3, "fabienz", y2019d03fabienz.PartOne, y2019d03fabienz.PartTwo)
+	register(2019, 4, "fabienz", y2019d04fabienz.PartOne, y2019d04fabienz.PartTwo)
+	register(2019, 5, "fabienz", y2019d05fabienz.PartOne, y2019d05fabienz.PartTwo)
+	register(2019, 6, "fabienz", y2019d06fabienz.PartOne, y2019d06fabienz.PartTwo)
+	register(2019, 7, "fabienz", y2019d07fabienz.PartOne, y2019d07fabienz.PartTwo)
+	register(2019, 8, "fabienz", y2019d08fabienz.PartOne, y2019d08fabienz.PartTwo)
+	register(2019, 9, "fabienz", y2019d09fabienz.PartOne, y2019d09fabienz.PartTwo)
+	register(2019, 10, "fabienz", y2019d10fabienz.PartOne, y2019d10fabienz.PartTwo)
+	register(2019, 11, "fabienz", y2019d11fabienz.PartOne, y2019d11fabienz.PartTwo)
+	register(2019, 12, "fabienz", y2019d12fabienz.PartOne, y2019d12fabienz.PartTwo)
+	register(2019, 13, "fabienz", y2019d13fabienz.PartOne, y2019d13fabienz.PartTwo)
+	register(2019, 14, "fabienz", y2019d14fabienz.PartOne, y2019d14fabienz.PartTwo)
+	register(2020, 1, "fabienz", y2020d01fabienz.PartOne, y2020d01fabienz.PartTwo)
+	register(2020, 2, "fabienz", y2020d02fabienz.PartOne, y2020d02fabienz.PartTwo)
+	register(2020, 3, "fabienz", y2020d03fabienz.PartOne, y2020d03fabienz.PartTwo)
+	register(2020, 4, "fabienz", y2020d04fabienz.PartOne, y2020d04fabienz.PartTwo)
+	register(2020, 5, "fabienz", y2020d05fabienz.PartOne, y2020d05fabienz.PartTwo)
+	register(2020, 6, "fabienz", y2020d06fabienz.PartOne, y2020d06fabienz.PartTwo)
+	register(2020, 7, "fabienz", y2020d07fabienz.PartOne, y2020d07fabienz.PartTwo)
+	register(2020, 8, "fabienz", y2020d08fabienz.PartOne, y2020d08fabienz.PartTwo)
+	register(2020, 9, "fabienz", y2020d09fabienz.PartOne, y2020d09fabienz.PartTwo)
+	register(2020, 10, "fabienz", y2020d10fabienz.PartOne, y2020d10fabienz.PartTwo)
+	register(2020, 11, "fabienz", y2020d11fabienz.PartOne, y2020d11fabienz.PartTwo)
+	register(2020, 12, "fabienz", y2020d12fabienz.PartOne, y2020d12fabienz.PartTwo)
+	register(2020, 13, "fabienz", y2020d13fabienz.PartOne, y2020d13fabienz.PartTwo)
+	register(2020, 14, "fabienz", y2020d14fabienz.PartOne, y2020d14fabienz.PartTwo)
+	register(2020, 15, "fabienz", y2020d15fabienz.PartOne, y2020d15fabienz.PartTwo)
+	register(2020, 16, "fabienz", y2020d16fabienz.PartOne, y2020d16fabienz.PartTwo)
+	register(2020, 17, "fabienz", y2020d17fabienz.PartOne, y2020d17fabienz.PartTwo)
+	register(2020, 18, "fabienz", y2020d18fabienz.PartOne, y2020d18fabienz.PartTwo)
+	register(2020, 19, "fabienz", y2020d19fabienz.PartOne, y2020d19fabienz.PartTwo)
+	register(2020, 20, "fabienz", y2020d20fabienz.PartOne, y2020d20fabienz.PartTwo)
+	register(2020, 21, "fabienz", y2020d21fabienz.PartOne, y2020d21fabienz.PartTwo)
+	register(2020, 22, "fabienz", y2020d22fabienz.PartOne, y2020d22fabienz.PartTwo)
+	register(2020, 23, "fabienz", y2020d23fabienz.PartOne, y2020d23fabienz.PartTwo)
+	register(2020, 24, "fabienz", y2020d24fabienz.PartOne, y2020d24fabienz.PartTwo)
+	register(2020, 25, "fabienz", y2020d25fabienz.PartOne, nil)
+	register(2021, 1, "fabienz", y2021d01fabienz.PartOne, y2021d01fabienz.PartTwo)
+	register(2021, 2, "fabienz", y2021d02fabienz.PartOne, y2021d02fabienz.PartTwo)
+	register(2021, 3, "fabienz", y2021d03fabienz.PartOne, y2021d03fabienz.PartTwo)
+	register(2021, 4, "fabienz", y2021d04fabienz.PartOne, y2021d04fabienz.PartTwo)
+	register(2021, 5, "fabienz", y2021d05fabienz.PartOne, y2021d05fabienz.PartTwo)
+	register(2021, 6, "fabienz", y2021d06fabienz.PartOne, y2021d06fabienz.PartTwo)
+	register(2021, 7, "fabienz", y2021d07fabienz.PartOne, y2021d07fabienz.PartTwo)
+	register(2021, 8, "fabienz", y2021d08fabienz.PartOne, y2021d08fabienz.PartTwo)
+	register(2021, 9, "fabienz", y2021d09fabienz.PartOne, y2021d09fabienz.PartTwo)
+	register(2021, 10, "fabienz", y2021d10fabienz.PartOne, y2021d10fabienz.PartTwo)
+	register(2021, 11, "fabienz", y2021d11fabienz.PartOne, y2021d11fabienz.PartTwo)
+	register(2021, 12, "fabienz", y2021d12fabienz.PartOne, y2021d12fabienz.PartTwo)
+	register(2021, 13, "fabienz", y2021d13fabienz.PartOne, y2021d13fabienz.PartTwo)
+	register(2021, 14, "fabienz", y2021d14fabienz.PartOne, y2021d14fabienz.PartTwo)
+	register(2021, 16, "fabienz", y2021d16fabienz.PartOne, y2021d16fabienz.PartTwo)
+	register(2021, 17, "fabienz", y2021d17fabienz.PartOne, y2021d17fabienz.PartTwo)
+	register(2021, 18, "fabienz", y2021d18fabienz.PartOne, y2021d18fabienz.PartTwo)
+	register(2021, 19, "fabienz", y2021d19fabienz.PartOne, y2021d19fabienz.PartTwo)
+	register(2021, 20, "fabienz", y2021d20fabienz.PartOne, y2021d20fabienz.PartTwo)
+	register(2021, 21, "fabienz", y2021d21fabienz.PartOne, y2021d21fabienz.PartTwo)
+	register(2021, 22, "fabienz", y2021d22fabienz.PartOne, y2021d22fabienz.PartTwo)
+	register(2021, 25, "fabienz", y2021d25fabienz.PartOne, nil)
+	register(2022, 1, "fabienz", y2022d01fabienz.PartOne, y2022d01fabienz.PartTwo)
+	register(2022, 2, "fabienz", y2022d02fabienz.PartOne, y2022d02fabienz.PartTwo)
+	register(2022, 3, "fabienz", y2022d03fabienz.PartOne, y2022d03fabienz.PartTwo)
+	register(2022, 4, "fabienz", y2022d04fabienz.PartOne, y2022d04fabienz.PartTwo)
+	register(2022, 5, "fabienz", y2022d05fabienz.PartOne, y2022d05fabienz.PartTwo)
+	register(2022, 6, "fabienz", y2022d06fabienz.PartOne, y2022d06fabienz.PartTwo)
+	register(2022, 7, "fabienz", y2022d07fabienz.PartOne, y2022d07fabienz.PartTwo)
+	register(2022, 8, "fabienz", y2022d08fabienz.PartOne, y2022d08fabienz.PartTwo)
+	register(2022, 9, "fabienz", y2022d09fabienz.PartOne, y2022d09fabienz.PartTwo)
+	register(2022, 10, "fabienz", y2022d10fabienz.PartOne, y2022d10fabienz.PartTwo)
+	register(2022, 11, "fabienz", y2022d11fabienz.PartOne, y2022d11fabienz.PartTwo)
+	register(2022, 12, "fabienz", y2022d12fabienz.PartOne, y2022d12fabienz.PartTwo)
+	register(2022, 13, "fabienz", y2022d13fabienz.PartOne, y2022d13fabienz.PartTwo)
+	register(2022, 14, "fabienz", y2022d14fabienz.PartOne, y2022d14fabienz.PartTwo)
+	register(2022, 15, "fabienz", y2022d15fabienz.PartOne, y2022d15fabienz.PartTwo)
+	register(2022, 16, "fabienz", y2022d16fabienz.PartOne, y2022d16fabienz.PartTwo)
+	register(2022, 17, "fabienz", y2022d17fabienz.PartOne, y2022d17fabienz.PartTwo)
+	register(2022, 18, "fabienz", y2022d18fabienz.PartOne, y2022d18fabienz.PartTwo)
+	register(2022, 19, "fabienz", y2022d19fabienz.PartOne, y2022d19fabienz.PartTwo)
+	register(2022, 20, "fabienz", y2022d20fabienz.PartOne, y2022d20fabienz.PartTwo)
+	register(2022, 21, "fabienz", y2022d21fabienz.PartOne, y2022d21fabienz.PartTwo)
+	register(2022, 22, "fabienz", y2022d22fabienz.PartOne, y2022d22fabienz.PartTwo)
+	register(2022, 23, "fabienz", y2022d23fabienz.PartOne, y2022d23fabienz.PartTwo)
+	register(2022, 24, "fabienz", y2022d24fabienz.PartOne, y2022d24fabienz.PartTwo)
+	register(2022, 25, "fabienz", y2022d25fabienz.PartOne, nil)
+	register(2024, 1, "fabienz", y2024d01fabienz.PartOne, y2024d01fabienz.PartTwo)
+	register(2024, 2, "fabienz", y2024d02fabienz.PartOne, y2024d02fabienz.PartTwo)
+	register(2024, 3, "fabienz", y2024d03fabienz.PartOne, y2024d03fabienz.PartTwo)
+	register(2024, 4, "fabienz", y2024d04fabienz.PartOne, y2024d04fabienz.PartTwo)
+	register(2024, 5, "fabienz", y2024d05fabienz.PartOne, y2024d05fabienz.PartTwo)
+	register(2024, 6, "fabienz", y2024d06fabienz.PartOne, y2024d06fabienz.PartTwo)
+}
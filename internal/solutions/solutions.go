@@ -0,0 +1,100 @@
+package solutions
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/fabienzucchet/adventofcode/helpers"
+)
+
+// A Key identifies the solution to one part of an Advent of Code puzzle.
+type Key struct {
+	Year, Day int
+	Author    string
+	Part      int
+}
+
+// Dir returns the path to the package holding the solution, relative to the
+// root of the repository.
+func (k Key) Dir() string {
+	return filepath.Join(
+		fmt.Sprintf("y%04d", k.Year),
+		fmt.Sprintf("d%02d", k.Day),
+		k.Author,
+	)
+}
+
+// InputFile returns the path to the solution's input, relative to the root of
+// the repository.
+func (k Key) InputFile() string {
+	return filepath.Join(k.Dir(), "testdata", "input.txt")
+}
+
+func (k Key) String() string {
+	return fmt.Sprintf("%d/%02d/%s part %d", k.Year, k.Day, k.Author, k.Part)
+}
+
+// registry holds all known solutions. It is filled by the generated code in
+// registry.go.
+var registry = make(map[Key]helpers.Solution)
+
+// register adds both parts of a puzzle's solution to the registry. A nil part
+// is ignored.
+func register(year, day int, author string, partOne, partTwo helpers.SolutionFunc) {
+	if partOne != nil {
+		registry[Key{Year: year, Day: day, Author: author, Part: 1}] = partOne
+	}
+	if partTwo != nil {
+		registry[Key{Year: year, Day: day, Author: author, Part: 2}] = partTwo
+	}
+}
+
+// Lookup returns the solution registered for key.
+func Lookup(key Key) (helpers.Solution, error) {
+	s, ok := registry[key]
+	if !ok {
+		return nil, fmt.Errorf("no solution registered for %s", key)
+	}
+	return s, nil
+}
+
+// Keys returns the keys of all registered solutions, sorted by year, day,
+// author and part.
+func Keys() []Key {
+	keys := make([]Key, 0, len(registry))
+	for k := range registry {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.Year != b.Year {
+			return a.Year < b.Year
+		}
+		if a.Day != b.Day {
+			return a.Day < b.Day
+		}
+		if a.Author != b.Author {
+			return a.Author < b.Author
+		}
+		return a.Part < b.Part
+	})
+
+	return keys
+}
+
+// Authors returns the authors of the solutions registered for the puzzle of
+// the given day and year, sorted.
+func Authors(year, day int) []string {
+	var authors []string
+	for _, k := range Keys() {
+		if k.Year != year || k.Day != day {
+			continue
+		}
+		if len(authors) == 0 || authors[len(authors)-1] != k.Author {
+			authors = append(authors, k.Author)
+		}
+	}
+	return authors
+}
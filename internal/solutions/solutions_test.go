@@ -0,0 +1,121 @@
+package solutions
+
+import (
+	"io"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	partOne := func(input io.Reader, answer io.Writer) error { return nil }
+	register(1, 1, "test", partOne, nil)
+	t.Cleanup(func() { delete(registry, Key{Year: 1, Day: 1, Author: "test", Part: 1}) })
+
+	tests := map[string]struct {
+		key   Key
+		found bool
+	}{
+		"registered": {
+			key:   Key{Year: 1, Day: 1, Author: "test", Part: 1},
+			found: true,
+		},
+		"nil part": {
+			key: Key{Year: 1, Day: 1, Author: "test", Part: 2},
+		},
+		"unknown author": {
+			key: Key{Year: 1, Day: 1, Author: "nobody", Part: 1},
+		},
+		"generated": {
+			key:   Key{Year: 2022, Day: 1, Author: "fabienz", Part: 2},
+			found: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			s, err := Lookup(test.key)
+			if !test.found {
+				if err == nil {
+					t.Fatalf("expected no solution for %s", test.key)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if s == nil {
+				t.Fatalf("expected a solution for %s", test.key)
+			}
+		})
+	}
+}
+
+func TestKeys(t *testing.T) {
+	keys := Keys()
+	if len(keys) != len(registry) {
+		t.Fatalf("expected %d keys, got %d", len(registry), len(keys))
+	}
+
+	sorted := sort.SliceIsSorted(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.Year != b.Year {
+			return a.Year < b.Year
+		}
+		if a.Day != b.Day {
+			return a.Day < b.Day
+		}
+		if a.Author != b.Author {
+			return a.Author < b.Author
+		}
+		return a.Part < b.Part
+	})
+	if !sorted {
+		t.Error("expected keys to be sorted by year, day, author and part")
+	}
+
+	for _, key := range keys {
+		if _, err := Lookup(key); err != nil {
+			t.Errorf("could not look up %s: %v", key, err)
+		}
+	}
+}
+
+func TestKeyPaths(t *testing.T) {
+	key := Key{Year: 2022, Day: 5, Author: "bob", Part: 1}
+
+	tests := map[string]struct {
+		got, expected string
+	}{
+		"Dir":       {key.Dir(), "y2022/d05/bob"},
+		"InputFile": {key.InputFile(), "y2022/d05/bob/testdata/input.txt"},
+		"String":    {key.String(), "2022/05/bob part 1"},
+	}
+
+	for name, test := range tests {
+		if test.got != test.expected {
+			t.Errorf("%s: expected %q, got %q", name, test.expected, test.got)
+		}
+	}
+}
+
+func TestAuthors(t *testing.T) {
+	partOne := func(input io.Reader, answer io.Writer) error { return nil }
+	register(1, 1, "bob", partOne, partOne)
+	register(1, 1, "alice", nil, partOne)
+	register(1, 2, "carol", partOne, nil)
+	t.Cleanup(func() {
+		for k := range registry {
+			if k.Year == 1 {
+				delete(registry, k)
+			}
+		}
+	})
+
+	if authors := Authors(1, 1); !reflect.DeepEqual(authors, []string{"alice", "bob"}) {
+		t.Errorf("expected authors [alice bob], got %v", authors)
+	}
+	if authors := Authors(1, 3); len(authors) != 0 {
+		t.Errorf("expected no authors, got %v", authors)
+	}
+}
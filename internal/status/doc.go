@@ -0,0 +1,3 @@
+// Package status reports progress on Advent of Code puzzles, by looking at the
+// state of the solutions in a working directory.
+package status
@@ -0,0 +1,107 @@
+package status
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Markers around the progress table in a Markdown document.
+const (
+	beginMarker = "<!-- status:begin -->"
+	endMarker   = "<!-- status:end -->"
+)
+
+// WriteCalendar writes a calendar of the stars earned each year to w, with one
+// column per day.
+func WriteCalendar(w io.Writer, years []Year) error {
+	var b strings.Builder
+
+	b.WriteString("    ")
+	for day := 1; day <= Days; day++ {
+		fmt.Fprintf(&b, "%3d", day)
+	}
+	b.WriteString("\n")
+
+	for _, y := range years {
+		fmt.Fprintf(&b, "%d", y.Year)
+		for _, d := range y.Days {
+			fmt.Fprintf(&b, "%3s", calendarCell(d))
+		}
+		fmt.Fprintf(&b, "  %2d/%d ⭐\n", y.Stars, 2*Days)
+	}
+
+	b.WriteString("\n** both parts solved, * one part solved, ~ in progress, . not started\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func calendarCell(d Day) string {
+	switch {
+	case d.Stars == 2:
+		return "**"
+	case d.Stars == 1:
+		return "*"
+	case d.Started():
+		return "~"
+	default:
+		return "."
+	}
+}
+
+// WriteMarkdown writes a table of the stars earned each year to w, with one
+// column per day.
+func WriteMarkdown(w io.Writer, years []Year) error {
+	var b strings.Builder
+
+	b.WriteString("| Year |")
+	for day := 1; day <= Days; day++ {
+		fmt.Fprintf(&b, " %d |", day)
+	}
+	b.WriteString(" Stars |\n|---:|")
+	b.WriteString(strings.Repeat(":-:|", Days))
+	b.WriteString("---:|\n")
+
+	for _, y := range years {
+		fmt.Fprintf(&b, "| %d |", y.Year)
+		for _, d := range y.Days {
+			fmt.Fprintf(&b, " %s |", markdownCell(d))
+		}
+		fmt.Fprintf(&b, " %d |\n", y.Stars)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func markdownCell(d Day) string {
+	switch {
+	case d.Stars > 0:
+		return strings.Repeat("⭐", d.Stars)
+	case d.Started():
+		return "🚧"
+	default:
+		return ""
+	}
+}
+
+// UpdateSection replaces the contents between the status markers of the
+// Markdown document doc with section.
+func UpdateSection(doc []byte, section string) ([]byte, error) {
+	begin := bytes.Index(doc, []byte(beginMarker))
+	end := bytes.Index(doc, []byte(endMarker))
+	if begin < 0 || end < begin {
+		return nil, fmt.Errorf("no %s and %s markers in document", beginMarker, endMarker)
+	}
+
+	var buf bytes.Buffer
+	buf.Write(doc[:begin+len(beginMarker)])
+	buf.WriteString("\n\n")
+	buf.WriteString(strings.TrimSpace(section))
+	buf.WriteString("\n\n")
+	buf.Write(doc[end:])
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,171 @@
+package status
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/fabienzucchet/adventofcode/internal/scaffolding"
+)
+
+// Days is the number of puzzles in a year of Advent of Code.
+const Days = 25
+
+var yearDirRegexp = regexp.MustCompile(`^y(\d{4})$`)
+
+// A Solution is the progress of an author on a puzzle.
+type Solution struct {
+	Author  string                `json:"author"`
+	PartOne scaffolding.PartState `json:"partOne"`
+	PartTwo scaffolding.PartState `json:"partTwo"`
+}
+
+// A Day is the progress on the puzzle of one day, by all authors.
+type Day struct {
+	Day int `json:"day"`
+	// Stars earned on the puzzle, ie. the number of parts solved by at least
+	// one author.
+	Stars     int        `json:"stars"`
+	Solutions []Solution `json:"solutions,omitempty"`
+}
+
+// Started reports whether any author started solving the puzzle.
+func (d Day) Started() bool {
+	return len(d.Solutions) > 0
+}
+
+// A Year is the progress on all puzzles of a year.
+type Year struct {
+	Year  int   `json:"year"`
+	Stars int   `json:"stars"`
+	Days  []Day `json:"days"`
+}
+
+// Years returns every year from the first to the last that has a directory in
+// workdir, in order.
+func Years(workdir string) ([]int, error) {
+	dir := workdir
+	if dir == "" {
+		dir = "."
+	}
+
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("listing %q: %w", dir, err)
+	}
+
+	var first, last int
+	for _, entry := range entries {
+		m := yearDirRegexp.FindStringSubmatch(entry.Name())
+		if m == nil || !entry.IsDir() {
+			continue
+		}
+		year, _ := strconv.Atoi(m[1])
+		if first == 0 || year < first {
+			first = year
+		}
+		if year > last {
+			last = year
+		}
+	}
+
+	var years []int
+	for year := first; first != 0 && year <= last; year++ {
+		years = append(years, year)
+	}
+	return years, nil
+}
+
+// Scan reports progress on the puzzles of each year in workdir. If author is
+// not empty, only the solutions of that author are considered.
+func Scan(workdir string, years []int, author string) ([]Year, error) {
+	var progress []Year
+	for _, year := range years {
+		y, err := scanYear(workdir, year, author)
+		if err != nil {
+			return nil, err
+		}
+		progress = append(progress, y)
+	}
+	return progress, nil
+}
+
+func scanYear(workdir string, year int, author string) (Year, error) {
+	y := Year{Year: year}
+
+	for day := 1; day <= Days; day++ {
+		d := Day{Day: day}
+
+		dayDir := filepath.Join(workdir, fmt.Sprintf("y%d", year), fmt.Sprintf("d%02d", day))
+		entries, err := ioutil.ReadDir(dayDir)
+		if err != nil && !os.IsNotExist(err) {
+			return Year{}, fmt.Errorf("listing %q: %w", dayDir, err)
+		}
+
+		var partOne, partTwo bool
+		for _, entry := range entries {
+			if !entry.IsDir() || (author != "" && entry.Name() != author) {
+				continue
+			}
+
+			s, err := inspect(filepath.Join(dayDir, entry.Name()))
+			if err != nil {
+				return Year{}, fmt.Errorf("inspecting %d/%02d/%s: %w", year, day, entry.Name(), err)
+			}
+			d.Solutions = append(d.Solutions, s)
+
+			partOne = partOne || s.PartOne == scaffolding.PartSolved
+			partTwo = partTwo || s.PartTwo == scaffolding.PartSolved
+		}
+		sort.Slice(d.Solutions, func(i, j int) bool { return d.Solutions[i].Author < d.Solutions[j].Author })
+
+		if partOne {
+			d.Stars++
+		}
+		if partTwo {
+			d.Stars++
+		}
+
+		y.Days = append(y.Days, d)
+		y.Stars += d.Stars
+	}
+
+	// The last puzzle has a single part: its second star is earned by getting
+	// all other stars.
+	last := &y.Days[Days-1]
+	if last.Stars == 1 && y.Stars == 2*Days-1 && !hasPartTwo(last.Solutions) {
+		last.Stars++
+		y.Stars++
+	}
+
+	return y, nil
+}
+
+// inspect reports the progress of the solution in packageDir.
+func inspect(packageDir string) (Solution, error) {
+	s := Solution{Author: filepath.Base(packageDir)}
+
+	var err error
+	if s.PartOne, err = scaffolding.InspectPart(packageDir, 1); err != nil {
+		return Solution{}, err
+	}
+	if s.PartTwo, err = scaffolding.InspectPart(packageDir, 2); err != nil {
+		return Solution{}, err
+	}
+
+	return s, nil
+}
+
+// hasPartTwo reports whether any of the solutions has a function for part two.
+func hasPartTwo(solutions []Solution) bool {
+	for _, s := range solutions {
+		if s.PartTwo != scaffolding.PartMissing {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+package status
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fabienzucchet/adventofcode/internal/scaffolding"
+)
+
+// writeSolution writes a solution with the given functions and example
+// outputs to workdir.
+func writeSolution(t *testing.T, workdir string, year, day int, author string, outputs map[string]string) {
+	t.Helper()
+
+	dir := filepath.Join(workdir, fmt.Sprintf("y%d", year), fmt.Sprintf("d%02d", day), author)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("could not create package: %v", err)
+	}
+
+	var src, tests strings.Builder
+	src.WriteString("package " + author + "\n")
+	tests.WriteString("package " + author + "\n")
+	for part, output := range outputs {
+		fmt.Fprintf(&src, "\nfunc %s() {}\n", part)
+		fmt.Fprintf(&tests, "\nfunc Example%s() {\n\t// Output: %s\n}\n", part, output)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "solution.go"), []byte(src.String()), 0644); err != nil {
+		t.Fatalf("could not write solution: %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "solution_test.go"), []byte(tests.String()), 0644); err != nil {
+		t.Fatalf("could not write tests: %v", err)
+	}
+}
+
+func TestScan(t *testing.T) {
+	const placeholder = "👉 Write the answer here 👈"
+
+	workdir := t.TempDir()
+	writeSolution(t, workdir, 2020, 1, "alice", map[string]string{"PartOne": "1", "PartTwo": placeholder})
+	writeSolution(t, workdir, 2020, 1, "bob", map[string]string{"PartOne": placeholder, "PartTwo": "2"})
+	writeSolution(t, workdir, 2020, 2, "bob", map[string]string{"PartOne": placeholder, "PartTwo": placeholder})
+	writeSolution(t, workdir, 2022, 25, "alice", map[string]string{"PartOne": "3"})
+
+	years, err := Years(workdir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmt.Sprint(years) != "[2020 2021 2022]" {
+		t.Fatalf("expected years 2020 to 2022, got %v", years)
+	}
+
+	tests := map[string]struct {
+		author string
+		stars  []int
+		// Stars of the first two days of 2020.
+		first, second int
+	}{
+		"all authors": {
+			stars: []int{2, 0, 1},
+			first: 2,
+		},
+		"one author": {
+			author: "bob",
+			stars:  []int{1, 0, 0},
+			first:  1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			progress, err := Scan(workdir, years, test.author)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for i, y := range progress {
+				if y.Stars != test.stars[i] {
+					t.Errorf("expected %d stars in %d, got %d", test.stars[i], y.Year, y.Stars)
+				}
+			}
+
+			days := progress[0].Days
+			if days[0].Stars != test.first || days[1].Stars != test.second {
+				t.Errorf("expected %d and %d stars on first days, got %d and %d", test.first, test.second, days[0].Stars, days[1].Stars)
+			}
+			if !days[1].Started() {
+				t.Error("expected second day to be in progress")
+			}
+			if s := days[1].Solutions[0]; s.PartOne != scaffolding.PartUnverified {
+				t.Errorf("expected part one of second day to be unverified, got %s", s.PartOne)
+			}
+		})
+	}
+}
+
+func TestLastDay(t *testing.T) {
+	workdir := t.TempDir()
+	for day := 1; day < Days; day++ {
+		writeSolution(t, workdir, 2022, day, "alice", map[string]string{"PartOne": "1", "PartTwo": "2"})
+	}
+	writeSolution(t, workdir, 2022, Days, "alice", map[string]string{"PartOne": "3"})
+
+	progress, err := Scan(workdir, []int{2022}, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if progress[0].Stars != 2*Days {
+		t.Errorf("expected all stars once the last puzzle is solved, got %d", progress[0].Stars)
+	}
+}
+
+func TestUpdateSection(t *testing.T) {
+	doc := "# Title\n\n<!-- status:begin -->\nold table\n<!-- status:end -->\n\nMore.\n"
+
+	updated, err := UpdateSection([]byte(doc), "new table\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "# Title\n\n<!-- status:begin -->\n\nnew table\n\n<!-- status:end -->\n\nMore.\n"
+	if string(updated) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, updated)
+	}
+
+	if _, err := UpdateSection([]byte("# Title\n"), "new table"); err == nil {
+		t.Error("expected error without markers")
+	}
+}
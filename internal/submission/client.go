@@ -0,0 +1,102 @@
+package submission
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/fabienzucchet/adventofcode/internal/aocclient"
+)
+
+// maxRetries is how many times a rate-limited answer is submitted again.
+const maxRetries = 3
+
+// A Client submits answers to adventofcode.com.
+type Client struct {
+	aoc *aocclient.Client
+}
+
+// NewClient builds a client that submits answers through aoc, which must have
+// a session cookie.
+func NewClient(aoc *aocclient.Client) (*Client, error) {
+	if !aoc.LoggedIn() {
+		return nil, fmt.Errorf("no session cookie provided")
+	}
+
+	return &Client{aoc: aoc}, nil
+}
+
+// Submit sends answer to part of the puzzle of the given day and year, unless
+// ledger proves the answer is wrong. If adventofcode.com asks to wait before
+// submitting, Submit waits and tries again. Every attempt is recorded in the
+// ledger, which is then saved.
+func (c *Client) Submit(year, day, part int, answer string, ledger *Ledger) (Result, error) {
+	if err := ledger.Check(part, answer); err != nil {
+		return Result{}, fmt.Errorf("refusing to submit: %w", err)
+	}
+
+	var (
+		result Result
+		err    error
+	)
+	for try := 0; try <= maxRetries; try++ {
+		if wait := ledger.NotBefore.Sub(c.aoc.Now()); wait > 0 {
+			fmt.Printf("⏳ Waiting %s before submitting...\n", wait.Round(time.Second))
+			c.aoc.Sleep(wait)
+		}
+
+		result, err = c.post(year, day, part, answer)
+		if err != nil {
+			// Keep the attempts already recorded, and when to submit next.
+			if try > 0 {
+				if err := ledger.Save(); err != nil {
+					return Result{}, fmt.Errorf("saving ledger: %w", err)
+				}
+			}
+			return Result{}, err
+		}
+
+		submittedAt := c.aoc.Now()
+		ledger.Record(Attempt{
+			Part:        part,
+			Answer:      answer,
+			Verdict:     result.Verdict,
+			SubmittedAt: submittedAt,
+		})
+		if result.Wait > 0 {
+			ledger.NotBefore = submittedAt.Add(result.Wait)
+		}
+
+		if result.Verdict != VerdictRateLimited {
+			break
+		}
+	}
+
+	if err := ledger.Save(); err != nil {
+		return Result{}, fmt.Errorf("saving ledger: %w", err)
+	}
+
+	return result, nil
+}
+
+// post sends an answer to adventofcode.com and parses the response.
+func (c *Client) post(year, day, part int, answer string) (Result, error) {
+	path := fmt.Sprintf("/%d/day/%d/answer", year, day)
+
+	form := url.Values{}
+	form.Set("level", strconv.Itoa(part))
+	form.Set("answer", answer)
+
+	page, err := c.aoc.Post(path, form)
+	if err != nil {
+		return Result{}, err
+	}
+
+	result, err := ParseResponse(page)
+	if err != nil {
+		return Result{}, fmt.Errorf("parsing response from %q: %w", path, err)
+	}
+
+	return result, nil
+}
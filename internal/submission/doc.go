@@ -0,0 +1,4 @@
+// Package submission sends answers to adventofcode.com and keeps track of
+// every attempt in a ledger, so that known-wrong answers are never submitted
+// twice.
+package submission
@@ -0,0 +1,123 @@
+package submission
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// An Attempt is an answer submitted to adventofcode.com.
+type Attempt struct {
+	Part        int       `json:"part"`
+	Answer      string    `json:"answer"`
+	Verdict     Verdict   `json:"verdict"`
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+// A Ledger records every answer submitted for a puzzle.
+type Ledger struct {
+	Attempts []Attempt `json:"attempts"`
+	// Time before which adventofcode.com will not accept a new answer.
+	NotBefore time.Time `json:"not_before"`
+
+	// Path to the file the ledger is stored in.
+	path string
+}
+
+// LoadLedger reads the ledger stored in the file at path. If the file does not
+// exist, the ledger is empty.
+func LoadLedger(path string) (*Ledger, error) {
+	ledger := &Ledger{path: path}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return ledger, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading ledger: %w", err)
+	}
+
+	if err := json.Unmarshal(data, ledger); err != nil {
+		return nil, fmt.Errorf("parsing ledger %q: %w", path, err)
+	}
+
+	return ledger, nil
+}
+
+// Save writes the ledger to the file it was loaded from.
+func (l *Ledger) Save() error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding ledger: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return fmt.Errorf("creating directory %q: %w", filepath.Dir(l.path), err)
+	}
+
+	if err := ioutil.WriteFile(l.path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("writing ledger to file %q: %w", l.path, err)
+	}
+
+	return nil
+}
+
+// Record adds an attempt to the ledger.
+func (l *Ledger) Record(a Attempt) {
+	l.Attempts = append(l.Attempts, a)
+}
+
+// Correct returns the right answer to part, if it is known.
+func (l *Ledger) Correct(part int) (string, bool) {
+	for _, a := range l.Attempts {
+		if a.Part == part && a.Verdict == VerdictCorrect {
+			return a.Answer, true
+		}
+	}
+	return "", false
+}
+
+// Check returns an error if the ledger proves answer to part is wrong, or if
+// part is already solved.
+func (l *Ledger) Check(part int, answer string) error {
+	if correct, ok := l.Correct(part); ok {
+		if correct == answer {
+			return fmt.Errorf("part %d is already solved with answer %q", part, answer)
+		}
+		return fmt.Errorf("part %d is already solved with answer %q, not %q", part, correct, answer)
+	}
+
+	for _, a := range l.Attempts {
+		if a.Part == part && a.Answer == answer && a.Verdict.Wrong() {
+			return fmt.Errorf("answer %q was already submitted on %s: %s", answer, a.SubmittedAt.Format(time.RFC1123), a.Verdict)
+		}
+	}
+
+	n, err := strconv.Atoi(answer)
+	if err != nil {
+		// Answer is not a number, so brackets do not apply.
+		return nil
+	}
+
+	for _, a := range l.Attempts {
+		if a.Part != part {
+			continue
+		}
+		bound, err := strconv.Atoi(a.Answer)
+		if err != nil {
+			continue
+		}
+		if a.Verdict == VerdictTooHigh && n >= bound {
+			return fmt.Errorf("answer %d is not lower than %d, which is too high", n, bound)
+		}
+		if a.Verdict == VerdictTooLow && n <= bound {
+			return fmt.Errorf("answer %d is not higher than %d, which is too low", n, bound)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package submission
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A Verdict is adventofcode.com's judgement of a submitted answer.
+type Verdict int
+
+const (
+	// The response could not be understood.
+	VerdictUnknown Verdict = iota
+	// The answer is right.
+	VerdictCorrect
+	// The answer is wrong, with no further hint.
+	VerdictIncorrect
+	// The answer is wrong, and too high.
+	VerdictTooHigh
+	// The answer is wrong, and too low.
+	VerdictTooLow
+	// The answer was not checked because another was submitted too recently.
+	VerdictRateLimited
+	// The answer was not checked because the puzzle is already solved.
+	VerdictAlreadySolved
+)
+
+var verdictNames = map[Verdict]string{
+	VerdictUnknown:       "unknown",
+	VerdictCorrect:       "correct",
+	VerdictIncorrect:     "incorrect",
+	VerdictTooHigh:       "too high",
+	VerdictTooLow:        "too low",
+	VerdictRateLimited:   "rate limited",
+	VerdictAlreadySolved: "already solved",
+}
+
+func (v Verdict) String() string {
+	if name, ok := verdictNames[v]; ok {
+		return name
+	}
+	return fmt.Sprintf("Verdict(%d)", int(v))
+}
+
+// Wrong returns whether the verdict proves the answer is wrong.
+func (v Verdict) Wrong() bool {
+	return v == VerdictIncorrect || v == VerdictTooHigh || v == VerdictTooLow
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (v Verdict) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *Verdict) UnmarshalText(text []byte) error {
+	for verdict, name := range verdictNames {
+		if name == string(text) {
+			*v = verdict
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown verdict %q", text)
+}
+
+// A Result is the parsed response of adventofcode.com to a submitted answer.
+type Result struct {
+	Verdict Verdict
+	// How long to wait before submitting another answer.
+	Wait time.Duration
+	// The text of the response, stripped of HTML.
+	Message string
+}
+
+var (
+	articleRegexp = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagRegexp     = regexp.MustCompile(`<[^>]*>`)
+	spaceRegexp   = regexp.MustCompile(`\s+`)
+	// eg. "You have 1m 5s left to wait."
+	leftToWaitRegexp = regexp.MustCompile(`(?:(\d+)m )?(\d+)s left to wait`)
+	// eg. "please wait 5 minutes before trying again."
+	pleaseWaitRegexp = regexp.MustCompile(`(?i)please wait (one|\d+) minutes? before trying again`)
+)
+
+// ParseResponse reads the HTML page returned by adventofcode.com after an
+// answer was submitted.
+func ParseResponse(page []byte) (Result, error) {
+	match := articleRegexp.FindSubmatch(page)
+	if match == nil {
+		return Result{}, fmt.Errorf("no message in response")
+	}
+
+	msg := tagRegexp.ReplaceAllString(string(match[1]), "")
+	msg = strings.TrimSpace(spaceRegexp.ReplaceAllString(msg, " "))
+
+	result := Result{Message: msg}
+
+	switch {
+	case strings.Contains(msg, "That's the right answer"):
+		result.Verdict = VerdictCorrect
+	case strings.Contains(msg, "your answer is too high"):
+		result.Verdict = VerdictTooHigh
+	case strings.Contains(msg, "your answer is too low"):
+		result.Verdict = VerdictTooLow
+	case strings.Contains(msg, "That's not the right answer"):
+		result.Verdict = VerdictIncorrect
+	case strings.Contains(msg, "You gave an answer too recently"):
+		result.Verdict = VerdictRateLimited
+	case strings.Contains(msg, "Did you already complete it?"):
+		result.Verdict = VerdictAlreadySolved
+	}
+
+	if m := leftToWaitRegexp.FindStringSubmatch(msg); m != nil {
+		minutes, _ := strconv.Atoi(m[1])
+		seconds, _ := strconv.Atoi(m[2])
+		result.Wait = time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	} else if m := pleaseWaitRegexp.FindStringSubmatch(msg); m != nil {
+		minutes := 1
+		if m[1] != "one" {
+			minutes, _ = strconv.Atoi(m[1])
+		}
+		result.Wait = time.Duration(minutes) * time.Minute
+	}
+
+	return result, nil
+}
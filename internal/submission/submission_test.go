@@ -0,0 +1,212 @@
+package submission
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fabienzucchet/adventofcode/internal/aocclient"
+)
+
+const (
+	rightAnswerPage = `<html><body><main><article><p>That's the right answer! You are <em>one gold star</em> closer to saving Christmas. <a href="/2022/day/10#part2">[Continue to Part Two]</a></p></article></main></body></html>`
+	tooHighPage     = `<html><body><main><article><p>That's not the right answer; your answer is too high.  If you're stuck, make sure you're using the full input data. Please wait one minute before trying again. <a href="/2022/day/10">[Return to Day 10]</a></p></article></main></body></html>`
+	tooLowPage      = `<html><body><main><article><p>That's not the right answer; your answer is too low.  Because you have guessed incorrectly 5 times on this puzzle, please wait 5 minutes before trying again. <a href="/2022/day/10">[Return to Day 10]</a></p></article></main></body></html>`
+	wrongPage       = `<html><body><main><article><p>That's not the right answer.  If you're stuck, make sure you're using the full input data. <a href="/2022/day/10">[Return to Day 10]</a></p></article></main></body></html>`
+	tooRecentPage   = `<html><body><main><article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 1m 34s left to wait. <a href="/2022/day/10">[Return to Day 10]</a></p></article></main></body></html>`
+	solvedPage      = `<html><body><main><article><p>You don't seem to be solving the right level.  Did you already complete it? <a href="/2022/day/10">[Return to Day 10]</a></p></article></main></body></html>`
+)
+
+func TestParseResponse(t *testing.T) {
+	tests := map[string]struct {
+		page    string
+		verdict Verdict
+		wait    time.Duration
+	}{
+		"right answer": {page: rightAnswerPage, verdict: VerdictCorrect},
+		"too high":     {page: tooHighPage, verdict: VerdictTooHigh, wait: time.Minute},
+		"too low":      {page: tooLowPage, verdict: VerdictTooLow, wait: 5 * time.Minute},
+		"wrong":        {page: wrongPage, verdict: VerdictIncorrect},
+		"too recent":   {page: tooRecentPage, verdict: VerdictRateLimited, wait: time.Minute + 34*time.Second},
+		"solved":       {page: solvedPage, verdict: VerdictAlreadySolved},
+		"unknown":      {page: "<article>Hello!</article>", verdict: VerdictUnknown},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := ParseResponse([]byte(test.page))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Verdict != test.verdict {
+				t.Errorf("expected verdict %q, got %q", test.verdict, result.Verdict)
+			}
+			if result.Wait != test.wait {
+				t.Errorf("expected wait of %s, got %s", test.wait, result.Wait)
+			}
+		})
+	}
+
+	if _, err := ParseResponse([]byte("<html></html>")); err == nil {
+		t.Error("expected error for page without message")
+	}
+}
+
+func TestLedgerCheck(t *testing.T) {
+	ledger := &Ledger{Attempts: []Attempt{
+		{Part: 1, Answer: "100", Verdict: VerdictTooHigh},
+		{Part: 1, Answer: "10", Verdict: VerdictTooLow},
+		{Part: 1, Answer: "50", Verdict: VerdictIncorrect},
+		{Part: 1, Answer: "42", Verdict: VerdictRateLimited},
+		{Part: 2, Answer: "ABC", Verdict: VerdictCorrect},
+	}}
+
+	tests := map[string]struct {
+		part    int
+		answer  string
+		wantErr bool
+	}{
+		"inside bracket":       {part: 1, answer: "42"},
+		"known wrong":          {part: 1, answer: "50", wantErr: true},
+		"too high":             {part: 1, answer: "150", wantErr: true},
+		"too high bound":       {part: 1, answer: "100", wantErr: true},
+		"too low":              {part: 1, answer: "-3", wantErr: true},
+		"not a number":         {part: 1, answer: "ABC"},
+		"already solved":       {part: 2, answer: "ABC", wantErr: true},
+		"solved with other":    {part: 2, answer: "DEF", wantErr: true},
+		"other part unbounded": {part: 3, answer: "150"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ledger.Check(test.part, test.answer)
+			if test.wantErr && err == nil {
+				t.Error("expected error, got none")
+			}
+			if !test.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestClientSubmit(t *testing.T) {
+	var pages []string
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/2022/day/10/answer" {
+			http.NotFound(w, r)
+			return
+		}
+		if c, err := r.Cookie("session"); err != nil || c.Value != "secret" {
+			http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+			return
+		}
+		if r.FormValue("level") != "1" || r.FormValue("answer") != "42" {
+			t.Errorf("unexpected form: %v", r.Form)
+		}
+		page := pages[requests]
+		requests++
+		if page == "" {
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprint(w, page)
+	}))
+	defer server.Close()
+
+	now := time.Date(2022, time.December, 10, 6, 0, 0, 0, time.UTC)
+	var slept time.Duration
+	newClient := func(cookie string) *Client {
+		aoc, err := aocclient.New(aocclient.Config{
+			BaseURL:  server.URL,
+			Cookie:   cookie,
+			Interval: -1,
+			CacheDir: t.TempDir(),
+			Now:      func() time.Time { return now },
+			Sleep: func(d time.Duration) {
+				slept += d
+				now = now.Add(d)
+			},
+		})
+		if err != nil {
+			t.Fatalf("could not create adventofcode.com client: %v", err)
+		}
+
+		client, err := NewClient(aoc)
+		if err != nil {
+			t.Fatalf("could not create client: %v", err)
+		}
+
+		return client
+	}
+
+	client := newClient("secret")
+
+	ledger, err := LoadLedger(filepath.Join(t.TempDir(), "testdata", "ledger.json"))
+	if err != nil {
+		t.Fatalf("could not load ledger: %v", err)
+	}
+
+	pages = []string{tooRecentPage, rightAnswerPage}
+	result, err := client.Submit(2022, 10, 1, "42", ledger)
+	if err != nil {
+		t.Fatalf("could not submit: %v", err)
+	}
+	if result.Verdict != VerdictCorrect {
+		t.Errorf("expected verdict %q, got %q", VerdictCorrect, result.Verdict)
+	}
+	if expected := time.Minute + 34*time.Second; slept != expected {
+		t.Errorf("expected to wait %s, waited %s", expected, slept)
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+
+	saved, err := LoadLedger(ledger.path)
+	if err != nil {
+		t.Fatalf("could not load saved ledger: %v", err)
+	}
+	if len(saved.Attempts) != 2 {
+		t.Fatalf("expected 2 attempts in ledger, got %d", len(saved.Attempts))
+	}
+	if saved.Attempts[1].Verdict != VerdictCorrect {
+		t.Errorf("expected last attempt to be %q, got %q", VerdictCorrect, saved.Attempts[1].Verdict)
+	}
+
+	if _, err := client.Submit(2022, 10, 1, "42", saved); err == nil {
+		t.Error("expected solved part to be refused")
+	}
+	if requests != 2 {
+		t.Errorf("expected refused answer not to be sent, got %d requests", requests)
+	}
+
+	// Attempts are saved even if a retry fails.
+	ledger, err = LoadLedger(filepath.Join(t.TempDir(), "testdata", "ledger.json"))
+	if err != nil {
+		t.Fatalf("could not load ledger: %v", err)
+	}
+	pages, requests = []string{tooRecentPage, ""}, 0
+	if _, err := client.Submit(2022, 10, 1, "42", ledger); err == nil {
+		t.Fatal("expected failed retry to return an error")
+	}
+	saved, err = LoadLedger(ledger.path)
+	if err != nil {
+		t.Fatalf("could not load saved ledger: %v", err)
+	}
+	if len(saved.Attempts) != 1 || saved.Attempts[0].Verdict != VerdictRateLimited {
+		t.Errorf("expected the rate-limited attempt in the ledger, got %+v", saved.Attempts)
+	}
+	if saved.NotBefore.IsZero() {
+		t.Error("expected the ledger to record when to submit next")
+	}
+
+	_, err = newClient("wrong").Submit(2022, 10, 1, "42", &Ledger{})
+	if !errors.Is(err, aocclient.ErrNotLoggedIn) {
+		t.Errorf("expected error with wrong session cookie, got %v", err)
+	}
+}
@@ -30,7 +30,9 @@ func PartOne(input io.Reader, answer io.Writer) error {
 	intcode := opcode.Intcode{Program: instructions}
 
 	// Init the program
-	intcode.InitIntcode(12, 2)
+	if err := intcode.InitIntcode(12, 2); err != nil {
+		return fmt.Errorf("could not init intcode: %w", err)
+	}
 
 	// Run the program
 	intcode.RunIntcode()
@@ -51,23 +53,30 @@ func PartTwo(input io.Reader, answer io.Writer) error {
 		return fmt.Errorf("could not read input: %w", err)
 	}
 
+	if len(lines) != 1 {
+		return fmt.Errorf("expected 1 line, got %d", len(lines))
+	}
+
+	// Parse the input.
+	instructions, err := helpers.IntsFromString(lines[0], ",")
+	if err != nil {
+		return fmt.Errorf("could not parse intcode: %w", err)
+	}
+	program := opcode.Intcode{Program: instructions}
+
 	output := 0
 	result := -1
 
 	// Try all the possible combinations of noun and verb
 	for noun := 0; noun < 100 && output != 19690720; noun++ {
 		for verb := 0; verb < 100 && output != 19690720; verb++ {
-			// Parse the input.
-			instructions, err := helpers.IntsFromString(lines[0], ",")
-			if err != nil {
-				return fmt.Errorf("could not parse intcode: %w", err)
-			}
-
-			// Create the program
-			intcode := opcode.Intcode{Program: instructions}
+			// Create a fresh copy of the program
+			intcode := program.Clone()
 
 			// Init the program
-			intcode.InitIntcode(noun, verb)
+			if err := intcode.InitIntcode(noun, verb); err != nil {
+				return fmt.Errorf("could not init intcode: %w", err)
+			}
 
 			// Run the program
 			intcode.RunIntcode()
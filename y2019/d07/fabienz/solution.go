@@ -1,6 +1,7 @@
 package fabienz
 
 import (
+	"context"
 	"fmt"
 	"io"
 
@@ -67,47 +68,20 @@ func PartTwo(input io.Reader, answer io.Writer) error {
 		return fmt.Errorf("could not read input: %w", err)
 	}
 
+	// Parse the instructions.
+	instructions, err := helpers.IntsFromString(lines[0], ",")
+	if err != nil {
+		return fmt.Errorf("could not parse instructions: %w", err)
+	}
+
 	// Compute the thrust for each possible phase setting. Store the result in a slice.
 	var thrusts []int
 	for _, sequence := range permutations([]int{5, 6, 7, 8, 9}) {
-		// Init the 5 amplifiers.
-		var amplifiers []opcode.Intcode
-		for i := 0; i < 5; i++ {
-			// Parse the instructions.
-			instructions, err := helpers.IntsFromString(lines[0], ",")
-			if err != nil {
-				return fmt.Errorf("could not parse instructions: %w", err)
-			}
-
-			// Init the computer.
-			intcode := opcode.Intcode{Program: instructions, Inputs: []int{sequence[i]}}
-			amplifiers = append(amplifiers, intcode)
+		thrust, err := feedbackLoop(instructions, sequence)
+		if err != nil {
+			return err
 		}
-
-		// Store the output of the previous amplifier.
-		var previousOutput int
-
-		// Process the feedback loop while the last amplifier is not halted with a 99 code.
-		for amplifiers[4].Pos != -1 {
-
-			// Process the 5 amplifiers.
-			for i := 0; i < 5; i++ {
-				// Add the output of the previous amplifier to the inputs of the current amplifier.
-				amplifiers[i].Inputs = append(amplifiers[i].Inputs, previousOutput)
-
-				// Run the computer.
-				outputs, err := amplifiers[i].RunIntcode()
-				if err != nil {
-					return fmt.Errorf("could not run intcode: %w", err)
-				}
-
-				// Store the output of the current amplifier.
-				previousOutput = outputs[len(outputs)-1]
-			}
-		}
-
-		// Store the output of the last amplifier.
-		thrusts = append(thrusts, previousOutput)
+		thrusts = append(thrusts, thrust)
 	}
 
 	_, err = fmt.Fprintf(answer, "%d", helpers.MaxInts(thrusts))
@@ -118,6 +92,43 @@ func PartTwo(input io.Reader, answer io.Writer) error {
 	return nil
 }
 
+// Run the 5 amplifiers in a feedback loop, each in its own goroutine, and
+// return the last output of the last amplifier.
+func feedbackLoop(program []int, sequence []int) (int, error) {
+	// links[i] carries the inputs of amplifier i, ie. the outputs of the
+	// previous amplifier. The last amplifier sends its outputs back to the
+	// first one. Once the first amplifier halted, the last output is left in
+	// the buffer of its link.
+	var links [5]chan int
+	for i := range links {
+		links[i] = make(chan int, 1)
+	}
+	links[0] <- 0
+
+	// Stop the other amplifiers if one fails, so that they do not block
+	// forever on their links.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		// Init the computer with its own copy of the program and its phase
+		// setting, and run it.
+		intcode := opcode.Intcode{Program: append([]int(nil), program...), Inputs: []int{sequence[i]}}
+		go func(in <-chan int, out chan<- int) {
+			errs <- intcode.Run(ctx, in, out)
+		}(links[i], links[(i+1)%5])
+	}
+
+	for i := 0; i < 5; i++ {
+		if err := <-errs; err != nil {
+			return 0, fmt.Errorf("could not run intcode: %w", err)
+		}
+	}
+
+	return <-links[0], nil
+}
+
 // Generate all permutations of distinct integers between 0 and 4.
 func permutations(ints []int) [][]int {
 	var result [][]int
@@ -46,14 +46,13 @@ func PartTwo(input io.Reader, answer io.Writer) error {
 		return fmt.Errorf("could not parse picture: %w", err)
 	}
 
-	// Decode the picture.
-	decodedPicture := picture.Decode()
-
-	// Print the decoded picture.
-	// decodedPicture.String()
+	// Decode the picture, and read its letters.
+	letters, err := helpers.OCR(picture.Decode().Pixels())
+	if err != nil {
+		return fmt.Errorf("could not read picture: %w", err)
+	}
 
-	// The answer can be read by printing the image with the Print function.
-	_, err = fmt.Fprintf(answer, "%v", decodedPicture)
+	_, err = fmt.Fprintf(answer, "%s", letters)
 	if err != nil {
 		return fmt.Errorf("could not write answer: %w", err)
 	}
@@ -158,6 +157,15 @@ func (p Picture) Decode() Layer {
 	return decoded
 }
 
+// Pixels returns the grid of the pixels of a layer, true where they are white.
+func (l Layer) Pixels() *helpers.Grid[bool] {
+	pixels := helpers.NewGrid[bool](layerWidth, layerHeight)
+	for i, color := range l {
+		pixels.Set(helpers.Coord2D{X: i % layerWidth, Y: i / layerWidth}, color == 1)
+	}
+	return pixels
+}
+
 // Print a layer
 func (l Layer) String() {
 	for i := 0; i < layerHeight; i++ {
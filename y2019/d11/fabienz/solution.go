@@ -22,23 +22,13 @@ func PartOne(input io.Reader, answer io.Writer) error {
 		return fmt.Errorf("could not parse intcode program: %w", err)
 	}
 
-	intcode := opcode.Intcode{Program: program}
-
-	// Create the surface
-	surface := Surface{
-		Map:             make(map[Coordinate]bool),
-		RobotCoordinate: Coordinate{X: 0, Y: 0},
-		RobotDirection:  Coordinate{X: 0, Y: 1},
-		Intcode:         &intcode,
-		SurfacePainted:  make(map[Coordinate]bool),
+	// Paint the surface, starting on a black panel
+	robot := newRobot(program)
+	if err := robot.Run(); err != nil {
+		return fmt.Errorf("could not run intcode: %w", err)
 	}
 
-	// While the intcode is not finished, paint the surface
-	for surface.Intcode.Pos >= 0 {
-		surface.PaintOneStep()
-	}
-
-	_, err = fmt.Fprintf(answer, "%d", len(surface.SurfacePainted))
+	_, err = fmt.Fprintf(answer, "%d", len(robot.painted))
 	if err != nil {
 		return fmt.Errorf("could not write answer: %w", err)
 	}
@@ -60,60 +50,20 @@ func PartTwo(input io.Reader, answer io.Writer) error {
 		return fmt.Errorf("could not parse intcode program: %w", err)
 	}
 
-	intcode := opcode.Intcode{Program: program}
-
-	// Create the surface
-	Map := make(map[Coordinate]bool)
-	// The starting panel is white
-	Map[Coordinate{X: 0, Y: 0}] = true
-
-	surface := Surface{
-		Map:             Map,
-		RobotCoordinate: Coordinate{X: 0, Y: 0},
-		RobotDirection:  Coordinate{X: 0, Y: 1},
-		Intcode:         &intcode,
-		SurfacePainted:  make(map[Coordinate]bool),
-	}
-
-	// While the intcode is not finished, paint the surface
-	for surface.Intcode.Pos >= 0 {
-		surface.PaintOneStep()
-	}
-
-	// Print the surface
-	minX := 0
-	maxX := 0
-	minY := 0
-	maxY := 0
-	for coord := range surface.SurfacePainted {
-		if coord.X < minX {
-			minX = coord.X
-		}
-		if coord.X > maxX {
-			maxX = coord.X
-		}
-		if coord.Y < minY {
-			minY = coord.Y
-		}
-		if coord.Y > maxY {
-			maxY = coord.Y
-		}
-
+	// Paint the surface, starting on a white panel
+	robot := newRobot(program)
+	robot.World[robot.Pos] = white
+	if err := robot.Run(); err != nil {
+		return fmt.Errorf("could not run intcode: %w", err)
 	}
 
-	for x := minX; x <= maxX; x++ {
-		for y := minY; y <= maxY; y++ {
-			if surface.Map[Coordinate{X: x, Y: y}] {
-				fmt.Print("#")
-			} else {
-				fmt.Print(" ")
-			}
-		}
-		fmt.Println()
+	// Read the letters painted on the surface
+	letters, err := helpers.OCRString(opcode.Palette{black: ' ', white: '#'}.Render(robot.World))
+	if err != nil {
+		return fmt.Errorf("could not read hull: %w", err)
 	}
 
-	// The answer can be read in the console : PFKHECZU
-	_, err = fmt.Fprintf(answer, "%s", "PFKHECZU")
+	_, err = fmt.Fprintf(answer, "%s", letters)
 	if err != nil {
 		return fmt.Errorf("could not write answer: %w", err)
 	}
@@ -121,60 +71,57 @@ func PartTwo(input io.Reader, answer io.Writer) error {
 	return nil
 }
 
-// Coordinate represents a coordinate in a 2D space.
-type Coordinate struct {
-	X int
-	Y int
-}
+// Colors of the panels of the surface
+const (
+	black = 0
+	white = 1
+)
 
-// Represent the ship' surface
-type Surface struct {
-	// Map of coordinates to color
-	Map             map[Coordinate]bool
-	RobotCoordinate Coordinate
-	RobotDirection  Coordinate
-	Intcode         *opcode.Intcode
-	SurfacePainted  map[Coordinate]bool
+// Hull painting robot, driven by an intcode program
+type Robot struct {
+	*opcode.Driver
+	// Direction the robot faces, with Y increasing downwards
+	direction helpers.Coord2D
+	// Panels whose color changed
+	painted map[helpers.Coord2D]bool
 }
 
-// Iterate one step of the painting robot
-func (s *Surface) PaintOneStep() {
-	// Get the current color of the surface
-	var currentColor int
-	// if the surface is painted in white, the color is 1
-	if s.Map[s.RobotCoordinate] {
-		currentColor = 1
+// Create a robot facing up
+func newRobot(program []int) *Robot {
+	robot := &Robot{
+		direction: helpers.Coord2D{X: 0, Y: -1},
+		painted:   make(map[helpers.Coord2D]bool),
 	}
 
-	// Send the current color to the intcode
-	s.Intcode.Inputs = append(s.Intcode.Inputs, currentColor)
+	robot.Driver = opcode.NewDriver(program, 2, robot.paint)
+	// The robot sees the color of the panel below it
+	robot.Sense = func(d *opcode.Driver) (int, error) {
+		return d.World[d.Pos], nil
+	}
 
-	// Run the intcode
-	s.Intcode.RunIntcode()
+	return robot
+}
 
-	// Get the new color
-	newColor := s.Intcode.Outputs[0]
-	s.Intcode.Outputs = s.Intcode.Outputs[1:]
+// Paint the panel below the robot, then turn and move forward
+func (r *Robot) paint(d *opcode.Driver, message []int) error {
+	color, turn := message[0], message[1]
 
 	// Paint the surface
-	if newColor != currentColor {
-		s.SurfacePainted[s.RobotCoordinate] = true
-		s.Map[s.RobotCoordinate] = newColor == 1
+	if color != d.World[d.Pos] {
+		r.painted[d.Pos] = true
 	}
-
-	// Get the new direction
-	newDirection := s.Intcode.Outputs[0]
-	s.Intcode.Outputs = s.Intcode.Outputs[1:]
+	d.World[d.Pos] = color
 
 	// Update the direction
-	if newDirection == 0 {
+	if turn == 0 {
 		// Turn left 90°
-		s.RobotDirection = Coordinate{X: -s.RobotDirection.Y, Y: s.RobotDirection.X}
+		r.direction = helpers.Coord2D{X: r.direction.Y, Y: -r.direction.X}
 	} else {
 		// Turn right 90°
-		s.RobotDirection = Coordinate{X: s.RobotDirection.Y, Y: -s.RobotDirection.X}
+		r.direction = helpers.Coord2D{X: -r.direction.Y, Y: r.direction.X}
 	}
 
 	// Move the robot
-	s.RobotCoordinate = Coordinate{X: s.RobotCoordinate.X + s.RobotDirection.X, Y: s.RobotCoordinate.Y + s.RobotDirection.Y}
+	d.Pos = d.Pos.Add(r.direction)
+	return nil
 }
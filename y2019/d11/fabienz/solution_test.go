@@ -28,51 +28,16 @@ func ExamplePartTwo() {
 	}
 	defer file.Close()
 
+	// The robot paints:
+	// ###..####.#..#.#..#.####..##..####.#..#.
+	// #..#.#....#.#..#..#.#....#..#....#.#..#.
+	// #..#.###..##...####.###..#......#..#..#.
+	// ###..#....#.#..#..#.#....#.....#...#..#.
+	// #....#....#.#..#..#.#....#..#.#....#..#.
+	// #....#....#..#.#..#.####..##..####..##..
 	if err := PartTwo(file, os.Stdout); err != nil {
 		log.Fatalf("could not solve: %v", err)
 	}
-	// ######
-	//   #  #
-	//   #  #
-	//    ##
-
-	// ######
-	//    # #
-	//    # #
-	//      #
-
-	// ######
-	//    #
-	//  ## #
-	// #    #
-
-	// ######
-	//    #
-	//    #
-	// ######
-
-	// ######
-	// #  # #
-	// #  # #
-	// #    #
-
-	//  ####
-	// #    #
-	// #    #
-	//  #  #
-
-	// ##   #
-	// # #  #
-	// #  # #
-	// #   ##
-
-	//	#####
-	//
-	// #
-	// #
-	//
-	//	#####
-	//
 	// Output: PFKHECZU
 }
 
@@ -44,24 +44,15 @@ func PartTwo(input io.Reader, answer io.Writer) error {
 		return fmt.Errorf("could not init game: %w", err)
 	}
 
-	// Play the game while there are still block tiles: move the joystick to make the ball bounce on the paddle
-	for {
-		// Run the program
-		_, err := game.software.RunIntcode()
-		if err != nil {
-			return fmt.Errorf("could not run intcode: %w", err)
-		}
-
-		// Update the game board
-		game.updateBoard()
-
-		// Check if there are still block tiles
-		if game.countBlockTiles() == 0 {
-			break
-		}
+	// Play the game: move the joystick to make the ball bounce on the paddle
+	game.Sense = func(d *opcode.Driver) (int, error) {
+		return game.joystick(), nil
+	}
 
-		// Move the joystick
-		game.moveJoystick()
+	// Run the program until the game is over
+	err = game.Run()
+	if err != nil {
+		return fmt.Errorf("could not run intcode: %w", err)
 	}
 
 	_, err = fmt.Fprintf(answer, "%d", game.score)
@@ -72,102 +63,82 @@ func PartTwo(input io.Reader, answer io.Writer) error {
 	return nil
 }
 
+// Tiles of the board
+const (
+	block  = 2
+	paddle = 3
+	ball   = 4
+)
+
 type Game struct {
-	board    helpers.IntGrid2D
-	software opcode.Intcode
-	score    int
+	*opcode.Driver
+	score int
 }
 
 // Init the game by running the intcode program
-func initGame(program string, quarters int) (game Game, err error) {
+func initGame(program string, quarters int) (*Game, error) {
 	instructions, err := helpers.IntsFromString(program, ",")
 	if err != nil {
-		return game, fmt.Errorf("could not parse intcode: %w", err)
+		return nil, fmt.Errorf("could not parse intcode: %w", err)
 	}
 
-	// Init the intcode program
-	game.software = opcode.Intcode{Program: instructions}
+	// Init the intcode program, which draws the board
+	game := &Game{}
+	game.Driver = opcode.NewDriver(instructions, 3, game.draw)
 
 	// Insert the number of quarters
 	if quarters != 0 {
-		game.software.Program[0] = quarters
+		game.Intcode.Program[0] = quarters
 	}
 
 	// Run the program
-	outputs, err := game.software.RunIntcode()
-	if err != nil {
-		return game, fmt.Errorf("could not run intcode: %w", err)
-	}
-
-	// Check that the number of outputs is a multiple of 3
-	if len(outputs)%3 != 0 {
-		return game, fmt.Errorf("unexpected number of outputs: %d", len(outputs))
-	}
-
-	game.board = make(helpers.IntGrid2D)
-
-	// Update the board
-	err = game.updateBoard()
+	err = game.Run()
 	if err != nil {
-		return game, fmt.Errorf("could not update board: %w", err)
+		return nil, fmt.Errorf("could not run intcode: %w", err)
 	}
 
 	return game, nil
 }
 
-// Parse a board
-func (g *Game) updateBoard() error {
-	// Check that the number of outputs is a multiple of 3
-	if len(g.software.Outputs)%3 != 0 {
-		return fmt.Errorf("unexpected number of outputs: %d", len(g.software.Outputs))
-	}
-
-	// Parse the output into the grid
-	for i := 0; i < len(g.software.Outputs); i += 3 {
-		// If X=-1 and Y=0, the third output instruction is not a tile; the value instead specifies the new score to show in the segment display.
-		if g.software.Outputs[i] == -1 && g.software.Outputs[i+1] == 0 {
-			g.score = g.software.Outputs[i+2]
-			continue
-		}
-		g.board[helpers.Coord2D{X: g.software.Outputs[i], Y: g.software.Outputs[i+1]}] = g.software.Outputs[i+2]
+// Draw a tile on the board, or update the score
+func (g *Game) draw(d *opcode.Driver, message []int) error {
+	// If X=-1 and Y=0, the third output instruction is not a tile; the value instead specifies the new score to show in the segment display.
+	if message[0] == -1 && message[1] == 0 {
+		g.score = message[2]
+		return nil
 	}
-
-	// Reset the outputs
-	g.software.Outputs = []int{}
-
-	return nil
+	return opcode.SetTile(d, message)
 }
 
 // Count the number of block tiles
 func (g *Game) countBlockTiles() int {
 	var nbBlockTiles int
-	for _, tile := range g.board {
-		if tile == 2 {
+	for _, tile := range g.World {
+		if tile == block {
 			nbBlockTiles++
 		}
 	}
 	return nbBlockTiles
 }
 
-// Move the joystick
-func (g *Game) moveJoystick() {
+// Position of the joystick that moves the paddle towards the ball
+func (g *Game) joystick() int {
 	// Find the ball and the paddle
 	var ballX, paddleX int
-	for coord, tile := range g.board {
-		if tile == 4 {
+	for coord, tile := range g.World {
+		if tile == ball {
 			ballX = coord.X
 		}
-		if tile == 3 {
+		if tile == paddle {
 			paddleX = coord.X
 		}
 	}
 
 	// Move the joystick
 	if ballX > paddleX {
-		g.software.Inputs = append(g.software.Inputs, 1)
+		return 1
 	} else if ballX < paddleX {
-		g.software.Inputs = append(g.software.Inputs, -1)
-	} else {
-		g.software.Inputs = append(g.software.Inputs, 0)
+		return -1
 	}
+	return 0
 }
@@ -0,0 +1,60 @@
+package opcode
+
+import "strings"
+
+// MaxASCII is the largest value that ASCII programs output as text.
+const MaxASCII = 127
+
+// ASCII drives an intcode program that reads and writes text, one character
+// per value. Such programs often end by outputting a large integer, the answer
+// to the puzzle, that is not a character.
+type ASCII struct {
+	Intcode *Intcode
+}
+
+// NewASCII returns an ASCII wrapper around a new intcode program.
+func NewASCII(program []int) ASCII {
+	return ASCII{Intcode: &Intcode{Program: program}}
+}
+
+// WriteLine adds lines to the inputs of the program, each followed by a
+// newline.
+func (a ASCII) WriteLine(lines ...string) {
+	a.Intcode.Inputs = append(a.Intcode.Inputs, EncodeASCII(lines...)...)
+}
+
+// Run runs the program until it halts, faults, or waits for an input. It
+// returns the text the program output since the last call, and the integers
+// that follow it. See DecodeASCII.
+func (a ASCII) Run() (text string, results []int, err error) {
+	outputs, err := a.Intcode.RunIntcode()
+	a.Intcode.Outputs = nil
+
+	text, results = DecodeASCII(outputs)
+	return text, results, err
+}
+
+// EncodeASCII returns the values of lines, each followed by a newline.
+func EncodeASCII(lines ...string) []int {
+	var values []int
+	for _, line := range lines {
+		for _, r := range line {
+			values = append(values, int(r))
+		}
+		values = append(values, '\n')
+	}
+	return values
+}
+
+// DecodeASCII splits the outputs of a program into text, up to the first value
+// that is not an ASCII character, and the values from there on.
+func DecodeASCII(outputs []int) (text string, results []int) {
+	var b strings.Builder
+	for i, value := range outputs {
+		if value < 0 || value > MaxASCII {
+			return b.String(), outputs[i:]
+		}
+		b.WriteByte(byte(value))
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,85 @@
+package opcode
+
+import (
+	"fmt"
+	"testing"
+)
+
+// shout prints a prompt, reads a line and prints it back, then outputs the
+// length of the line and halts.
+const shout = `
+	OUT #62			; >
+	OUT #10
+loop:	IN [char]
+	EQ [char], #10, [end]
+	JNZ [end], done
+	OUT [char]
+	ADD [length], #1000, [length]
+	JNZ #1, loop
+done:	OUT #10
+	OUT [length]
+	HLT
+char:	DATA 0
+end:	DATA 0
+length:	DATA 0
+`
+
+func TestASCII(t *testing.T) {
+	program, err := Assemble(shout)
+	if err != nil {
+		t.Fatalf("could not assemble: %v", err)
+	}
+	a := NewASCII(program)
+
+	text, results, err := a.Run()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if text != ">\n" || results != nil || a.Intcode.State != StateAwaitingInput {
+		t.Fatalf("expected prompt and program awaiting input, got %q, %v and %s", text, results, a.Intcode.State)
+	}
+
+	a.WriteLine("hello")
+	text, results, err = a.Run()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if text != "hello\n" || fmt.Sprint(results) != "[5000]" {
+		t.Errorf("expected %q and [5000], got %q and %v", "hello\n", text, results)
+	}
+	if a.Intcode.State != StateHalted {
+		t.Errorf("expected program to be %s, got %s", StateHalted, a.Intcode.State)
+	}
+}
+
+func TestDecodeASCII(t *testing.T) {
+	tests := map[string]struct {
+		outputs []int
+		text    string
+		results []int
+	}{
+		"text": {
+			outputs: EncodeASCII("#.", ".#"),
+			text:    "#.\n.#\n",
+		},
+		"result": {
+			outputs: append(EncodeASCII("ok"), 19350258),
+			text:    "ok\n",
+			results: []int{19350258},
+		},
+		"negative": {
+			outputs: []int{'a', -1, 'b'},
+			text:    "a",
+			results: []int{-1, 'b'},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			text, results := DecodeASCII(test.outputs)
+			if text != test.text || fmt.Sprint(results) != fmt.Sprint(test.results) {
+				t.Errorf("expected %q and %v, got %q and %v", test.text, test.results, text, results)
+			}
+		})
+	}
+}
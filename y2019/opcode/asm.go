@@ -0,0 +1,200 @@
+package opcode
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	labelRegexp    = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	relativeRegexp = regexp.MustCompile(`^\[\s*rb\s*(?:([+-])\s*(\d+))?\s*\]$`)
+)
+
+// Encode returns the values of the instruction in a program.
+func (i Instruction) Encode() []int {
+	if i.Data != nil {
+		return append([]int{}, i.Data...)
+	}
+
+	value := int(i.Opcode)
+	factor := 100
+	for _, p := range i.Params {
+		value += int(p.Mode) * factor
+		factor *= 10
+	}
+
+	values := []int{value}
+	for _, p := range i.Params {
+		values = append(values, p.Value)
+	}
+	return values
+}
+
+// Assemble turns the source code of an intcode program into the program. The
+// source code has one instruction per line, in the syntax of Instruction's
+// String method:
+//
+//	loop:   IN [rb+1]          ; Read a value.
+//	        JZ [rb+1], end
+//	        OUT [rb+1]
+//	        JNZ #1, loop
+//	end:    HLT
+//	buffer: DATA 0, 0, 0
+//
+// Lines may start with a label, and comments start with a semicolon. Operands
+// are immediate values (#4), positions ([100]), positions relative to the
+// relative base ([rb+3]), or labels. A label stands for its address as an
+// immediate value (loop or #loop), or as a position ([buffer]). DATA lists
+// values, or labels, to copy as is in the program.
+func Assemble(source string) ([]int, error) {
+	type line struct {
+		number   int
+		mnemonic string
+		operands []string
+	}
+
+	// Find the address of every label, and the instructions to assemble.
+	var (
+		lines   []line
+		labels  = make(map[string]int)
+		address int
+	)
+	for i, text := range strings.Split(source, "\n") {
+		number := i + 1
+		if j := strings.Index(text, ";"); j >= 0 {
+			text = text[:j]
+		}
+		text = strings.TrimSpace(text)
+
+		if j := strings.Index(text, ":"); j >= 0 {
+			label := strings.TrimSpace(text[:j])
+			if !labelRegexp.MatchString(label) {
+				return nil, fmt.Errorf("line %d: invalid label %q", number, label)
+			}
+			if _, ok := labels[label]; ok {
+				return nil, fmt.Errorf("line %d: duplicate label %q", number, label)
+			}
+			labels[label] = address
+			text = strings.TrimSpace(text[j+1:])
+		}
+		if text == "" {
+			continue
+		}
+
+		l := line{number: number, mnemonic: strings.ToUpper(strings.Fields(text)[0])}
+		if rest := strings.TrimSpace(text[len(l.mnemonic):]); rest != "" {
+			for _, operand := range strings.Split(rest, ",") {
+				l.operands = append(l.operands, strings.TrimSpace(operand))
+			}
+		}
+		lines = append(lines, l)
+
+		if l.mnemonic == "DATA" {
+			address += len(l.operands)
+		} else {
+			address += 1 + len(l.operands)
+		}
+	}
+
+	var program []int
+	for _, l := range lines {
+		if l.mnemonic == "DATA" {
+			for _, operand := range l.operands {
+				value, err := operandValue(operand, labels)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %w", l.number, err)
+				}
+				program = append(program, value)
+			}
+			continue
+		}
+
+		op, ok := ParseMnemonic(l.mnemonic)
+		if !ok {
+			return nil, fmt.Errorf("line %d: unknown mnemonic %q", l.number, l.mnemonic)
+		}
+		if len(l.operands) != op.Params() {
+			return nil, fmt.Errorf("line %d: %s takes %d operands, got %d", l.number, l.mnemonic, op.Params(), len(l.operands))
+		}
+
+		instruction := Instruction{Address: len(program), Opcode: op}
+		for j, operand := range l.operands {
+			p, err := parseParam(operand, labels)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", l.number, err)
+			}
+			if p.Mode == ModeImmediate && j == opcodes[op].write {
+				return nil, fmt.Errorf("line %d: %s cannot write to immediate operand %q", l.number, l.mnemonic, operand)
+			}
+			instruction.Params = append(instruction.Params, p)
+		}
+		program = append(program, instruction.Encode()...)
+	}
+
+	return program, nil
+}
+
+// Format returns the source code of instructions, as understood by Assemble.
+// Jump targets are labeled with Label.
+func Format(instructions []Instruction) string {
+	labels := Labels(instructions)
+
+	var b strings.Builder
+	for _, instruction := range instructions {
+		if instruction.JumpTarget {
+			fmt.Fprintf(&b, "%s:\n", Label(instruction.Address))
+		}
+		fmt.Fprintf(&b, "\t%s\n", instruction.Format(labels))
+	}
+	return b.String()
+}
+
+// mnemonics maps mnemonics to their opcode.
+var mnemonics = func() map[string]Opcode {
+	m := make(map[string]Opcode)
+	for op, o := range opcodes {
+		m[o.mnemonic] = op
+	}
+	return m
+}()
+
+// parseParam parses an operand of an instruction.
+func parseParam(operand string, labels map[string]int) (Param, error) {
+	if m := relativeRegexp.FindStringSubmatch(operand); m != nil {
+		offset := 0
+		if m[2] != "" {
+			offset, _ = strconv.Atoi(m[2])
+		}
+		if m[1] == "-" {
+			offset = -offset
+		}
+		return Param{Mode: ModeRelative, Value: offset}, nil
+	}
+
+	if strings.HasPrefix(operand, "[") && strings.HasSuffix(operand, "]") {
+		value, err := operandValue(strings.TrimSpace(operand[1:len(operand)-1]), labels)
+		return Param{Mode: ModePosition, Value: value}, err
+	}
+
+	value, err := operandValue(strings.TrimPrefix(operand, "#"), labels)
+	return Param{Mode: ModeImmediate, Value: value}, err
+}
+
+// operandValue returns the value of an integer or a label.
+func operandValue(operand string, labels map[string]int) (int, error) {
+	if labelRegexp.MatchString(operand) {
+		address, ok := labels[operand]
+		if !ok {
+			return 0, fmt.Errorf("unknown label %q", operand)
+		}
+		return address, nil
+	}
+
+	value, err := strconv.Atoi(operand)
+	if err != nil {
+		return 0, fmt.Errorf("invalid operand %q", operand)
+	}
+	return value, nil
+}
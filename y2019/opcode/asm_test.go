@@ -0,0 +1,173 @@
+package opcode
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// run assembles source, runs it with inputs, and returns its outputs.
+func run(t *testing.T, source string, inputs ...int) []int {
+	t.Helper()
+
+	program, err := Assemble(source)
+	if err != nil {
+		t.Fatalf("could not assemble program: %v", err)
+	}
+
+	intcode := Intcode{Program: program, Inputs: inputs}
+	outputs, err := intcode.RunIntcode()
+	if err != nil {
+		t.Fatalf("could not run program: %v", err)
+	}
+	if intcode.State != StateHalted {
+		t.Fatalf("expected program to halt, got %s", intcode.State)
+	}
+
+	return outputs
+}
+
+func TestOpcodes(t *testing.T) {
+	tests := map[string]struct {
+		source  string
+		inputs  []int
+		outputs []int
+	}{
+		"add immediate": {
+			source:  "ADD #2, #3, [x]\nOUT [x]\nHLT\nx: DATA 0",
+			outputs: []int{5},
+		},
+		"add position": {
+			source:  "ADD [a], [b], [a]\nOUT [a]\nHLT\na: DATA 7\nb: DATA -2",
+			outputs: []int{5},
+		},
+		"multiply relative": {
+			source:  "ARB a\nMUL [rb+0], [rb+1], [rb+2]\nOUT [rb+2]\nHLT\na: DATA 6, 7, 0",
+			outputs: []int{42},
+		},
+		"input": {
+			source:  "IN [x]\nIN [rb+0]\nOUT [x]\nOUT [0]\nHLT\nx: DATA 0",
+			inputs:  []int{3, 4},
+			outputs: []int{3, 4},
+		},
+		"jump if true": {
+			source:  "IN [x]\nJNZ [x], skip\nOUT #1\nskip: OUT #2\nHLT\nx: DATA 0",
+			inputs:  []int{1},
+			outputs: []int{2},
+		},
+		"jump if false": {
+			source:  "IN [x]\nJZ [x], skip\nOUT #1\nskip: OUT #2\nHLT\nx: DATA 0",
+			inputs:  []int{1},
+			outputs: []int{1, 2},
+		},
+		"less than": {
+			source:  "LT #1, #2, [x]\nOUT [x]\nLT #2, #1, [x]\nOUT [x]\nHLT\nx: DATA 0",
+			outputs: []int{1, 0},
+		},
+		"equals": {
+			source:  "EQ #1, #1, [x]\nOUT [x]\nEQ #1, #2, [x]\nOUT [x]\nHLT\nx: DATA 0",
+			outputs: []int{1, 0},
+		},
+		"relative base": {
+			source:  "ARB #3\nARB #-1\nOUT [rb-2]\nOUT [rb+1]\nHLT",
+			outputs: []int{109, -1},
+		},
+		"memory beyond program": {
+			source:  "ADD #1, #2, [1000]\nOUT [1000]\nOUT [2000]\nHLT",
+			outputs: []int{3, 0},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			outputs := run(t, test.source, test.inputs...)
+			if fmt.Sprint(outputs) != fmt.Sprint(test.outputs) {
+				t.Errorf("expected outputs %v, got %v", test.outputs, outputs)
+			}
+		})
+	}
+}
+
+func TestAssembleErrors(t *testing.T) {
+	tests := map[string]string{
+		"unknown mnemonic": "NOP",
+		"operand count":    "ADD #1, #2",
+		"immediate write":  "IN #1",
+		"unknown label":    "JNZ #1, nowhere",
+		"duplicate label":  "a: HLT\na: HLT",
+		"invalid label":    "1a: HLT",
+		"invalid operand":  "OUT [x",
+		"invalid data":     "DATA 1, two",
+	}
+
+	for name, source := range tests {
+		t.Run(name, func(t *testing.T) {
+			if program, err := Assemble(source); err == nil {
+				t.Errorf("expected error, got program %v", program)
+			}
+		})
+	}
+}
+
+// randomInstruction returns a valid instruction at address, with random
+// parameters.
+func randomInstruction(r *rand.Rand, address int) Instruction {
+	ops := []Opcode{OpAdd, OpMultiply, OpInput, OpOutput, OpJumpIfTrue, OpJumpIfFalse, OpLessThan, OpEquals, OpAdjustRelativeBase, OpHalt}
+	op := ops[r.Intn(len(ops))]
+
+	instruction := Instruction{Address: address, Opcode: op}
+	for j := 0; j < op.Params(); j++ {
+		mode := Mode(r.Intn(3))
+		if mode == ModeImmediate && j == opcodes[op].write {
+			mode = ModePosition
+		}
+		instruction.Params = append(instruction.Params, Param{Mode: mode, Value: r.Intn(2000) - 1000})
+	}
+	return instruction
+}
+
+func TestRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(2019))
+
+	for n := 0; n < 200; n++ {
+		// Random instructions decode to themselves.
+		var (
+			instructions []Instruction
+			program      []int
+		)
+		for i := 0; i < 20; i++ {
+			instruction := randomInstruction(r, len(program))
+			instructions = append(instructions, instruction)
+			program = append(program, instruction.Encode()...)
+		}
+
+		for _, expected := range instructions {
+			decoded, err := Decode(program, expected.Address)
+			if err != nil {
+				t.Fatalf("could not decode %s: %v", expected, err)
+			}
+			if decoded.String() != expected.String() {
+				t.Fatalf("expected %s, decoded %s", expected, decoded)
+			}
+		}
+
+		// Any program, made of instructions or not, disassembles to source
+		// that assembles back to the program.
+		if n%2 == 1 {
+			for i := range program {
+				if r.Intn(5) == 0 {
+					program[i] = r.Intn(20000) - 10000
+				}
+			}
+		}
+
+		source := Format(Disassemble(program))
+		assembled, err := Assemble(source)
+		if err != nil {
+			t.Fatalf("could not assemble:\n%s\nerror: %v", source, err)
+		}
+		if fmt.Sprint(assembled) != fmt.Sprint(program) {
+			t.Fatalf("expected program %v, got %v from source:\n%s", program, assembled, source)
+		}
+	}
+}
@@ -0,0 +1,330 @@
+package opcode
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// DefaultHistory is the number of steps a debugger can step back by default.
+const DefaultHistory = 10000
+
+// ErrNoHistory is returned when stepping back past the oldest step the
+// debugger remembers.
+var ErrNoHistory = errors.New("no step to undo")
+
+// A StopReason tells why a debugger stopped running a program.
+type StopReason int
+
+const (
+	// The debugger ran the steps it was asked to.
+	StopStep StopReason = iota
+	// The program reached a breakpoint.
+	StopBreakpoint
+	// The program wrote to a watched memory cell.
+	StopWatchpoint
+	// The program waits for an input.
+	StopInput
+	// The program halted.
+	StopHalt
+	// The program faulted.
+	StopFault
+)
+
+var stopReasonNames = map[StopReason]string{
+	StopStep:       "step",
+	StopBreakpoint: "breakpoint",
+	StopWatchpoint: "watchpoint",
+	StopInput:      "awaiting input",
+	StopHalt:       "halted",
+	StopFault:      "faulted",
+}
+
+func (r StopReason) String() string {
+	if name, ok := stopReasonNames[r]; ok {
+		return name
+	}
+	return fmt.Sprintf("StopReason(%d)", int(r))
+}
+
+// A Stop describes where and why a debugger stopped.
+type Stop struct {
+	Reason StopReason
+	// Position of the next instruction.
+	Pos int
+	// For watchpoints, the address of the memory cell, and its values before
+	// and after it was written.
+	Address, Old, New int
+}
+
+// A write is a change to a memory cell.
+type write struct {
+	address, old int
+}
+
+// An undo holds what is needed to undo a step.
+type undo struct {
+	pos, relativeBase int
+	state             State
+	// Memory cell written by the step, if any.
+	write *write
+	// Input consumed by the step, if any.
+	input *int
+	// Number of outputs before the step.
+	outputs int
+}
+
+// A Debugger runs an intcode program step by step, stopping on breakpoints
+// and watchpoints. It remembers the last steps, so that they can be undone.
+//
+// Steps that send outputs to an OutputFunc, or that read inputs from an
+// InputFunc, cannot be undone entirely: the outputs stay sent, and the inputs
+// are pushed back to Inputs.
+type Debugger struct {
+	Intcode *Intcode
+	// Number of steps the debugger can step back.
+	History int
+
+	breakpoints   map[int]bool
+	opBreakpoints map[Opcode]bool
+	watchpoints   map[int]bool
+	// Ring buffer of the last steps: count steps, the oldest at head.
+	undos       []undo
+	head, count int
+}
+
+// NewDebugger returns a debugger for intcode.
+func NewDebugger(intcode *Intcode) *Debugger {
+	return &Debugger{
+		Intcode:       intcode,
+		History:       DefaultHistory,
+		breakpoints:   make(map[int]bool),
+		opBreakpoints: make(map[Opcode]bool),
+		watchpoints:   make(map[int]bool),
+	}
+}
+
+// Break sets a breakpoint on the instruction at address.
+func (d *Debugger) Break(address int) {
+	d.breakpoints[address] = true
+}
+
+// BreakOn sets a breakpoint on all instructions with opcode op.
+func (d *Debugger) BreakOn(op Opcode) {
+	d.opBreakpoints[op] = true
+}
+
+// Watch sets a watchpoint on the memory cell at address.
+func (d *Debugger) Watch(address int) {
+	d.watchpoints[address] = true
+}
+
+// Clear removes all breakpoints and watchpoints on address.
+func (d *Debugger) Clear(address int) {
+	delete(d.breakpoints, address)
+	delete(d.watchpoints, address)
+}
+
+// ClearOn removes the breakpoint on opcode op.
+func (d *Debugger) ClearOn(op Opcode) {
+	delete(d.opBreakpoints, op)
+}
+
+// Breakpoints returns the addresses and opcodes with breakpoints, and the
+// addresses with watchpoints.
+func (d *Debugger) Breakpoints() (addresses []int, ops []Opcode, watched []int) {
+	for address := range d.breakpoints {
+		addresses = append(addresses, address)
+	}
+	for op := range d.opBreakpoints {
+		ops = append(ops, op)
+	}
+	for address := range d.watchpoints {
+		watched = append(watched, address)
+	}
+	sort.Ints(addresses)
+	sort.Slice(ops, func(i, j int) bool { return ops[i] < ops[j] })
+	sort.Ints(watched)
+	return addresses, ops, watched
+}
+
+// Poke sets the memory cell at address to value.
+func (d *Debugger) Poke(address, value int) error {
+	return d.Intcode.SetValue(address, value)
+}
+
+// Step runs up to n instructions, and stops early on breakpoints, watchpoints,
+// or when the program cannot run anymore.
+func (d *Debugger) Step(n int) (Stop, error) {
+	for i := 0; i < n; i++ {
+		stop, err := d.step()
+		if err != nil || stop.Reason != StopStep {
+			return stop, err
+		}
+		if i < n-1 && d.atBreakpoint() {
+			return d.stop(StopBreakpoint), nil
+		}
+	}
+	return d.stop(StopStep), nil
+}
+
+// Continue runs the program until a breakpoint or a watchpoint, or until the
+// program cannot run anymore. The instruction at the current position always
+// runs, even if it has a breakpoint.
+func (d *Debugger) Continue() (Stop, error) {
+	for {
+		stop, err := d.step()
+		if err != nil || stop.Reason != StopStep {
+			return stop, err
+		}
+		if d.atBreakpoint() {
+			return d.stop(StopBreakpoint), nil
+		}
+	}
+}
+
+// Back undoes up to n steps. It returns ErrNoHistory if no step could be
+// undone.
+func (d *Debugger) Back(n int) error {
+	if d.count == 0 {
+		return ErrNoHistory
+	}
+
+	for i := 0; i < n && d.count > 0; i++ {
+		u := d.undos[(d.head+d.count-1)%len(d.undos)]
+		d.count--
+
+		intcode := d.Intcode
+		if u.write != nil {
+			if err := intcode.SetValue(u.write.address, u.write.old); err != nil {
+				return err
+			}
+		}
+		if u.input != nil {
+			intcode.Inputs = append([]int{*u.input}, intcode.Inputs...)
+		}
+		if len(intcode.Outputs) > u.outputs {
+			intcode.Outputs = intcode.Outputs[:u.outputs]
+		}
+		intcode.Pos, intcode.RelativeBase, intcode.State = u.pos, u.relativeBase, u.state
+	}
+
+	return nil
+}
+
+// step runs the instruction at the current position, and remembers how to
+// undo it.
+func (d *Debugger) step() (Stop, error) {
+	intcode := d.Intcode
+	switch intcode.State {
+	case StateHalted:
+		return d.stop(StopHalt), nil
+	case StateFaulted:
+		return d.stop(StopFault), nil
+	}
+
+	u := undo{
+		pos:          intcode.Pos,
+		relativeBase: intcode.RelativeBase,
+		state:        intcode.State,
+		outputs:      len(intcode.Outputs),
+	}
+
+	instruction, decodeErr := intcode.Instruction(intcode.Pos)
+	if decodeErr == nil {
+		if j := opcodes[instruction.Opcode].write; j >= 0 {
+			address := instruction.Params[j].Value
+			if instruction.Params[j].Mode == ModeRelative {
+				address += intcode.RelativeBase
+			}
+			if old, err := intcode.GetValue(address); err == nil {
+				u.write = &write{address: address, old: old}
+			}
+		}
+	}
+	inputs := len(intcode.Inputs)
+	if inputs > 0 {
+		input := intcode.Inputs[0]
+		u.input = &input
+	}
+
+	intcode.State = StateRunning
+	err := intcode.ComputeStep()
+	if intcode.State == StateAwaitingInput {
+		// Nothing happened.
+		return d.stop(StopInput), nil
+	}
+
+	switch {
+	case len(intcode.Inputs) < inputs:
+		// The input came from Inputs, and was remembered above.
+	case err == nil && instruction.Opcode == OpInput && u.write != nil:
+		// The input came from the InputFunc.
+		input, _ := intcode.GetValue(u.write.address)
+		u.input = &input
+	default:
+		u.input = nil
+	}
+	d.remember(u)
+
+	if err != nil {
+		return d.stop(StopFault), err
+	}
+	if intcode.State == StateHalted {
+		return d.stop(StopHalt), nil
+	}
+	if u.write != nil && d.watchpoints[u.write.address] {
+		stop := d.stop(StopWatchpoint)
+		stop.Address, stop.Old = u.write.address, u.write.old
+		stop.New, _ = intcode.GetValue(u.write.address)
+		return stop, nil
+	}
+
+	return d.stop(StopStep), nil
+}
+
+// remember adds u to the undo log, forgetting the oldest steps beyond History.
+func (d *Debugger) remember(u undo) {
+	if d.History <= 0 {
+		return
+	}
+	if len(d.undos) != d.History {
+		d.resize()
+	}
+
+	d.undos[(d.head+d.count)%len(d.undos)] = u
+	if d.count < len(d.undos) {
+		d.count++
+	} else {
+		// The oldest step was overwritten.
+		d.head = (d.head + 1) % len(d.undos)
+	}
+}
+
+// resize makes the undo log hold History steps, keeping the most recent ones.
+func (d *Debugger) resize() {
+	undos := make([]undo, d.History)
+	kept := d.count
+	if kept > d.History {
+		kept = d.History
+	}
+	for i := 0; i < kept; i++ {
+		undos[i] = d.undos[(d.head+d.count-kept+i)%len(d.undos)]
+	}
+	d.undos, d.head, d.count = undos, 0, kept
+}
+
+// atBreakpoint reports whether the instruction at the current position has a
+// breakpoint.
+func (d *Debugger) atBreakpoint() bool {
+	pos := d.Intcode.Pos
+	if d.breakpoints[pos] {
+		return true
+	}
+	word, err := d.Intcode.GetValue(pos)
+	return err == nil && d.opBreakpoints[Opcode(word%100)]
+}
+
+func (d *Debugger) stop(reason StopReason) Stop {
+	return Stop{Reason: reason, Pos: d.Intcode.Pos}
+}
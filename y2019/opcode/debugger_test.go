@@ -0,0 +1,166 @@
+package opcode
+
+import (
+	"fmt"
+	"testing"
+)
+
+// counter counts down from its input, outputting each value, then halts.
+const counter = `
+	IN [n]
+loop:
+	OUT [n]
+	ADD [n], #-1, [n]
+	JNZ [n], loop
+	HLT
+n:	DATA 0
+`
+
+func newTestDebugger(t *testing.T, source string) *Debugger {
+	t.Helper()
+
+	program, err := Assemble(source)
+	if err != nil {
+		t.Fatalf("could not assemble program: %v", err)
+	}
+	return NewDebugger(&Intcode{Program: program})
+}
+
+func TestDebuggerStops(t *testing.T) {
+	d := newTestDebugger(t, counter)
+
+	expect := func(step string, stop Stop, err error, reason StopReason, pos int) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", step, err)
+		}
+		if stop.Reason != reason || stop.Pos != pos {
+			t.Errorf("%s: expected to stop on %s at %d, got %s at %d", step, reason, pos, stop.Reason, stop.Pos)
+		}
+	}
+
+	stop, err := d.Continue()
+	expect("without input", stop, err, StopInput, 0)
+
+	d.Intcode.Inputs = []int{3}
+	stop, err = d.Step(1)
+	expect("step", stop, err, StopStep, 2)
+
+	d.BreakOn(OpJumpIfTrue)
+	stop, err = d.Continue()
+	expect("opcode breakpoint", stop, err, StopBreakpoint, 8)
+	d.ClearOn(OpJumpIfTrue)
+
+	d.Watch(12)
+	stop, err = d.Continue()
+	expect("watchpoint", stop, err, StopWatchpoint, 8)
+	if stop.Address != 12 || stop.Old != 2 || stop.New != 1 {
+		t.Errorf("expected cell 12 to change from 2 to 1, got %+v", stop)
+	}
+	d.Clear(12)
+
+	d.Break(11)
+	stop, err = d.Continue()
+	expect("address breakpoint", stop, err, StopBreakpoint, 11)
+
+	stop, err = d.Continue()
+	expect("halt", stop, err, StopHalt, 11)
+
+	if fmt.Sprint(d.Intcode.Outputs) != "[3 2 1]" {
+		t.Errorf("expected outputs [3 2 1], got %v", d.Intcode.Outputs)
+	}
+}
+
+func TestDebuggerBack(t *testing.T) {
+	// Writes beyond the end of the program are undone too.
+	d := newTestDebugger(t, "\tADD #1, #1, [100]\n"+counter)
+	d.Intcode.Inputs = []int{2}
+
+	initial := snapshot(d.Intcode)
+	var states []string
+	for d.Intcode.State != StateHalted {
+		states = append(states, snapshot(d.Intcode))
+		if _, err := d.Step(1); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	for i := len(states) - 1; i >= 0; i-- {
+		if err := d.Back(1); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if state := snapshot(d.Intcode); state != states[i] {
+			t.Fatalf("expected state after stepping back to be:\n%s\ngot:\n%s", states[i], state)
+		}
+	}
+
+	if snapshot(d.Intcode) != initial {
+		t.Errorf("expected initial state after stepping back all the way")
+	}
+	if err := d.Back(1); err != ErrNoHistory {
+		t.Errorf("expected %v, got %v", ErrNoHistory, err)
+	}
+}
+
+func TestDebuggerHistory(t *testing.T) {
+	d := newTestDebugger(t, counter)
+	d.History = 2
+	d.Intcode.Inputs = []int{5}
+
+	if _, err := d.Step(5); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := d.Back(5); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Intcode.Pos != 8 {
+		t.Errorf("expected to step back only 2 steps, to position 8, got %d", d.Intcode.Pos)
+	}
+}
+
+func TestDebuggerHistoryResize(t *testing.T) {
+	d := newTestDebugger(t, counter)
+	d.History = 4
+	d.Intcode.Inputs = []int{5}
+
+	// Wrap around the history, then shrink it.
+	if _, err := d.Step(7); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	d.History = 2
+	if _, err := d.Step(1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := d.Back(5); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Intcode.Pos != 8 || d.Intcode.Outputs[len(d.Intcode.Outputs)-1] != 4 {
+		t.Errorf("expected to step back only 2 steps, to position 8 after output 4, got %s", snapshot(d.Intcode))
+	}
+}
+
+// BenchmarkDebuggerHistory steps through a program for longer than the
+// history, so that the oldest steps keep being forgotten.
+func BenchmarkDebuggerHistory(b *testing.B) {
+	program, err := Assemble(counter)
+	if err != nil {
+		b.Fatalf("could not assemble program: %v", err)
+	}
+	d := NewDebugger(&Intcode{Program: program, Inputs: []int{1 << 40}})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := d.Step(1); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+// snapshot returns the state of intcode, and its memory up to address 127.
+func snapshot(intcode *Intcode) string {
+	memory := make([]int, 128)
+	for i := range memory {
+		memory[i], _ = intcode.GetValue(i)
+	}
+	return fmt.Sprint(intcode.Pos, intcode.RelativeBase, intcode.State, intcode.Inputs, intcode.Outputs, memory)
+}
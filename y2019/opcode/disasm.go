@@ -0,0 +1,125 @@
+package opcode
+
+import "sort"
+
+// Disassemble decodes the instructions of program, and returns them in order
+// along with the regions of data between them.
+//
+// Instructions are found by following the program's control flow from address
+// 0: instructions continue to the next one, and jumps to immediate addresses
+// continue at their target. Jumps to computed addresses, like returns from
+// functions, cannot be followed. The code they lead to usually follows an
+// unconditional jump that called the function, so once the control flow is
+// exhausted, the values after unconditional jumps are decoded too, as long as
+// they do not overlap with instructions found before. Values that are never
+// reached, or that do not decode, are data.
+func Disassemble(program []int) []Instruction {
+	code := make(map[int]Instruction)
+	// Addresses taken by the parameters of decoded instructions.
+	covered := make(map[int]bool)
+	targets := make(map[int]bool)
+
+	// Addresses the control flow certainly reaches, and addresses following
+	// unconditional jumps.
+	pending, deferred := []int{0}, []int{}
+	for len(pending) > 0 || len(deferred) > 0 {
+		var address int
+		if len(pending) > 0 {
+			address, pending = pending[len(pending)-1], pending[:len(pending)-1]
+		} else {
+			address, deferred = deferred[0], deferred[1:]
+		}
+
+		for {
+			if _, ok := code[address]; ok || covered[address] {
+				break
+			}
+			instruction, err := Decode(program, address)
+			if err != nil || overlaps(instruction, code, covered) {
+				break
+			}
+
+			code[address] = instruction
+			for j := 1; j < instruction.Len(); j++ {
+				covered[address+j] = true
+			}
+
+			if target, ok := instruction.Target(); ok {
+				targets[target] = true
+				pending = append(pending, target)
+			}
+			if instruction.Opcode == OpHalt {
+				break
+			}
+			if unconditional(instruction) {
+				deferred = append(deferred, address+instruction.Len())
+				break
+			}
+			address += instruction.Len()
+		}
+	}
+
+	addresses := make([]int, 0, len(code))
+	for address := range code {
+		addresses = append(addresses, address)
+	}
+	sort.Ints(addresses)
+
+	var instructions []Instruction
+	next := 0
+	for _, address := range addresses {
+		instructions = append(instructions, data(program, next, address, targets)...)
+		instruction := code[address]
+		instruction.JumpTarget = targets[address]
+		instructions = append(instructions, instruction)
+		next = address + instruction.Len()
+	}
+	instructions = append(instructions, data(program, next, len(program), targets)...)
+
+	return instructions
+}
+
+// Labels returns the addresses of instructions that jumps target, and that
+// deserve a label in listings.
+func Labels(instructions []Instruction) map[int]bool {
+	labels := make(map[int]bool)
+	for _, instruction := range instructions {
+		if instruction.JumpTarget {
+			labels[instruction.Address] = true
+		}
+	}
+	return labels
+}
+
+// unconditional reports whether instruction is a jump that is always taken.
+func unconditional(instruction Instruction) bool {
+	if !instruction.Opcode.Jump() || instruction.Params[0].Mode != ModeImmediate {
+		return false
+	}
+	return (instruction.Params[0].Value != 0) == (instruction.Opcode == OpJumpIfTrue)
+}
+
+// overlaps reports whether the parameters of instruction take addresses that
+// are already decoded.
+func overlaps(instruction Instruction, code map[int]Instruction, covered map[int]bool) bool {
+	for j := 1; j < instruction.Len(); j++ {
+		if _, ok := code[instruction.Address+j]; ok || covered[instruction.Address+j] {
+			return true
+		}
+	}
+	return false
+}
+
+// data returns the regions of data of program between addresses start and
+// end. Regions are split at jump targets, so that they can be labeled.
+func data(program []int, start, end int, targets map[int]bool) []Instruction {
+	var regions []Instruction
+	for address := start; address < end; address++ {
+		if address == start || targets[address] {
+			regions = append(regions, Instruction{Address: address, Data: []int{}, JumpTarget: targets[address]})
+		}
+		region := &regions[len(regions)-1]
+		region.Data = append(region.Data, program[address])
+	}
+	return regions
+}
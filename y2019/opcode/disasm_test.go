@@ -0,0 +1,101 @@
+package opcode
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	tests := map[string]struct {
+		program []int
+		listing string
+		err     bool
+	}{
+		"modes": {
+			program: []int{21101, 3, 4, 100},
+			listing: "ADD #3, #4, [rb+100]",
+		},
+		"negative relative": {
+			program: []int{204, -3},
+			listing: "OUT [rb-3]",
+		},
+		"jump target": {
+			program: []int{1105, 1, 12},
+			listing: "JNZ #1, #12",
+		},
+		"halt": {
+			program: []int{99},
+			listing: "HLT",
+		},
+		"unknown opcode": {
+			program: []int{42},
+			err:     true,
+		},
+		"unknown mode": {
+			program: []int{304, 0},
+			err:     true,
+		},
+		"immediate write": {
+			program: []int{10001, 0, 0, 0},
+			err:     true,
+		},
+		"truncated": {
+			program: []int{1, 0, 0},
+			err:     true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			instruction, err := Decode(test.program, 0)
+			if test.err {
+				if err == nil {
+					t.Errorf("expected error, got %s", instruction)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if instruction.String() != test.listing {
+				t.Errorf("expected %q, got %q", test.listing, instruction)
+			}
+		})
+	}
+}
+
+func TestDisassemble(t *testing.T) {
+	program := []int{
+		// Jump over data, to 5.
+		1105, 1, 5,
+		// Data.
+		7, 42,
+		// Output the data and halt.
+		4, 4, 99,
+		// Data after the end of the program.
+		3,
+	}
+
+	instructions := Disassemble(program)
+	labels := Labels(instructions)
+
+	var listing []string
+	for _, instruction := range instructions {
+		line := instruction.Format(labels)
+		if instruction.JumpTarget {
+			line = Label(instruction.Address) + ": " + line
+		}
+		listing = append(listing, line)
+	}
+
+	expected := []string{
+		"JNZ #1, L0005",
+		"DATA 7, 42",
+		"L0005: OUT [4]",
+		"HLT",
+		"DATA 3",
+	}
+	if strings.Join(listing, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected listing:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(listing, "\n"))
+	}
+}
@@ -0,0 +1,134 @@
+package opcode
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fabienzucchet/adventofcode/helpers"
+)
+
+// A Driver couples an intcode program to a 2D world, that the program explores
+// or draws. The program reads inputs from Sense, for instance what a robot
+// sees, and sends messages of Arity outputs to Handle, which updates the world.
+type Driver struct {
+	Intcode *Intcode
+	World   helpers.IntGrid2D
+	// Position of the robot the program drives, if any.
+	Pos helpers.Coord2D
+	// Number of outputs in a message of the program.
+	Arity int
+	// Optional source of the inputs of the program, once its Inputs are empty.
+	Sense func(d *Driver) (int, error)
+	// Handles a message of the program. The message is only valid during the
+	// call.
+	Handle func(d *Driver, message []int) error
+
+	message []int
+}
+
+// NewDriver returns a driver for a new intcode program, with an empty world.
+func NewDriver(program []int, arity int, handle func(d *Driver, message []int) error) *Driver {
+	return &Driver{
+		Intcode: &Intcode{Program: program},
+		World:   make(helpers.IntGrid2D),
+		Arity:   arity,
+		Handle:  handle,
+	}
+}
+
+// Run runs the program until it halts, or until it waits for an input and
+// there is no Sense function. In that last case, add inputs to the program and
+// call Run again to resume it.
+//
+// While it runs, the driver replaces the Output function of the program, and
+// its Input function if Sense is set. They are restored when Run returns. Run
+// returns an error if the program halts in the middle of a message.
+func (d *Driver) Run() error {
+	if d.Arity <= 0 {
+		return fmt.Errorf("invalid arity %d", d.Arity)
+	}
+	if d.World == nil {
+		d.World = make(helpers.IntGrid2D)
+	}
+
+	input, output := d.Intcode.Input, d.Intcode.Output
+	defer func() { d.Intcode.Input, d.Intcode.Output = input, output }()
+
+	if d.Sense != nil {
+		d.Intcode.Input = func() (int, error) { return d.Sense(d) }
+	}
+	d.Intcode.Output = func(value int) error {
+		d.message = append(d.message, value)
+		if len(d.message) < d.Arity {
+			return nil
+		}
+		message := d.message
+		d.message = d.message[:0]
+		return d.Handle(d, message)
+	}
+
+	if _, err := d.Intcode.RunIntcode(); err != nil {
+		return err
+	}
+	if d.Intcode.State == StateHalted && len(d.message) > 0 {
+		return fmt.Errorf("program halted with an incomplete message of %d values", len(d.message))
+	}
+	return nil
+}
+
+// SetTile handles messages of 3 outputs, the X and Y coordinates of a cell of
+// the world and its new value.
+func SetTile(d *Driver, message []int) error {
+	if len(message) != 3 {
+		return fmt.Errorf("expected 3 values to set a tile, got %d", len(message))
+	}
+	d.World[helpers.Coord2D{X: message[0], Y: message[1]}] = message[2]
+	return nil
+}
+
+// A Palette maps the values of the cells of a world to characters.
+type Palette map[int]rune
+
+// Render draws world, with X increasing to the right and Y increasing
+// downwards. Missing cells are drawn as spaces, and values that are not in the
+// palette as question marks.
+func (p Palette) Render(world helpers.IntGrid2D) string {
+	if len(world) == 0 {
+		return ""
+	}
+
+	var minX, maxX, minY, maxY int
+	first := true
+	for c := range world {
+		if first || c.X < minX {
+			minX = c.X
+		}
+		if first || c.X > maxX {
+			maxX = c.X
+		}
+		if first || c.Y < minY {
+			minY = c.Y
+		}
+		if first || c.Y > maxY {
+			maxY = c.Y
+		}
+		first = false
+	}
+
+	var b strings.Builder
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			value, ok := world[helpers.Coord2D{X: x, Y: y}]
+			switch r, known := p[value]; {
+			case !ok:
+				b.WriteRune(' ')
+			case !known:
+				b.WriteRune('?')
+			default:
+				b.WriteRune(r)
+			}
+		}
+		b.WriteRune('\n')
+	}
+	return b.String()
+}
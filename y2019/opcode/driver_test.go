@@ -0,0 +1,91 @@
+package opcode
+
+import (
+	"testing"
+
+	"github.com/fabienzucchet/adventofcode/helpers"
+)
+
+// walker moves a robot right, reading what it sees, and marks each cell it
+// leaves with what it saw, plus 1. It stops when it sees a wall, marked 9.
+const walker = `
+loop:	IN [seen]
+	EQ [seen], #9, [wall]
+	JNZ [wall], end
+	ADD [seen], #1, [mark]
+	OUT [mark]
+	OUT #1
+	JNZ #1, loop
+end:	HLT
+seen:	DATA 0
+wall:	DATA 0
+mark:	DATA 0
+`
+
+func TestDriver(t *testing.T) {
+	program, err := Assemble(walker)
+	if err != nil {
+		t.Fatalf("could not assemble: %v", err)
+	}
+
+	d := NewDriver(program, 2, func(d *Driver, message []int) error {
+		d.World[d.Pos] = message[0]
+		d.Pos = d.Pos.Add(helpers.Coord2D{X: message[1]})
+		return nil
+	})
+	d.World[helpers.Coord2D{X: 1}] = 1
+	d.World[helpers.Coord2D{X: 3}] = 9
+	d.Sense = func(d *Driver) (int, error) {
+		return d.World[d.Pos], nil
+	}
+
+	if err := d.Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Intcode.State != StateHalted || d.Pos != (helpers.Coord2D{X: 3}) {
+		t.Errorf("expected robot to halt in front of the wall, got %s at %v", d.Intcode.State, d.Pos)
+	}
+
+	palette := Palette{1: '.', 2: 'o', 9: '#'}
+	if world := palette.Render(d.World); world != ".o.#\n" {
+		t.Errorf("expected world %q, got %q", ".o.#\n", world)
+	}
+}
+
+func TestDriverRestoresHooks(t *testing.T) {
+	program, err := Assemble(walker)
+	if err != nil {
+		t.Fatalf("could not assemble: %v", err)
+	}
+
+	d := NewDriver(program, 2, func(d *Driver, message []int) error { return nil })
+	var outputs []int
+	d.Intcode.Output = func(value int) error {
+		outputs = append(outputs, value)
+		return nil
+	}
+	d.Sense = func(d *Driver) (int, error) { return 9, nil }
+
+	if err := d.Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Intcode.Input != nil || d.Intcode.Output == nil {
+		t.Fatal("expected Run to restore the Input and Output functions of the program")
+	}
+	if err := d.Intcode.Output(1); err != nil || len(outputs) != 1 {
+		t.Errorf("expected the restored Output function to receive outputs, got %v", outputs)
+	}
+}
+
+func TestSetTile(t *testing.T) {
+	// Draws a tile with a color, then one without, then an incomplete message.
+	d := NewDriver([]int{104, 0, 104, 0, 104, 1, 104, 1, 104, 1, 104, 5, 104, 3, 104, 0, 99}, 3, SetTile)
+	if err := d.Run(); err == nil {
+		t.Error("expected error when the program halts in the middle of a message")
+	}
+
+	palette := Palette{1: '#'}
+	if world := palette.Render(d.World); world != "# \n ?\n" {
+		t.Errorf("expected world %q, got %q", "# \n ?\n", world)
+	}
+}
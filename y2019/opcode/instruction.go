@@ -0,0 +1,215 @@
+package opcode
+
+import (
+	"fmt"
+	"strings"
+)
+
+// An Opcode identifies the operation of an instruction.
+type Opcode int
+
+const (
+	OpAdd                Opcode = 1
+	OpMultiply           Opcode = 2
+	OpInput              Opcode = 3
+	OpOutput             Opcode = 4
+	OpJumpIfTrue         Opcode = 5
+	OpJumpIfFalse        Opcode = 6
+	OpLessThan           Opcode = 7
+	OpEquals             Opcode = 8
+	OpAdjustRelativeBase Opcode = 9
+	OpHalt               Opcode = 99
+)
+
+// Mnemonics and number of parameters of each opcode.
+var opcodes = map[Opcode]struct {
+	mnemonic string
+	params   int
+	// Index of the parameter the instruction writes to, or -1.
+	write int
+}{
+	OpAdd:                {"ADD", 3, 2},
+	OpMultiply:           {"MUL", 3, 2},
+	OpInput:              {"IN", 1, 0},
+	OpOutput:             {"OUT", 1, -1},
+	OpJumpIfTrue:         {"JNZ", 2, -1},
+	OpJumpIfFalse:        {"JZ", 2, -1},
+	OpLessThan:           {"LT", 3, 2},
+	OpEquals:             {"EQ", 3, 2},
+	OpAdjustRelativeBase: {"ARB", 1, -1},
+	OpHalt:               {"HLT", 0, -1},
+}
+
+// Mnemonic returns the name of the opcode in assembly listings, eg. "ADD".
+func (op Opcode) Mnemonic() string {
+	if o, ok := opcodes[op]; ok {
+		return o.mnemonic
+	}
+	return fmt.Sprintf("OP%d", int(op))
+}
+
+// ParseMnemonic returns the opcode with the given mnemonic, in any case.
+func ParseMnemonic(mnemonic string) (Opcode, bool) {
+	op, ok := mnemonics[strings.ToUpper(mnemonic)]
+	return op, ok
+}
+
+// Params returns the number of parameters of the opcode.
+func (op Opcode) Params() int {
+	return opcodes[op].params
+}
+
+// Jump reports whether the opcode is a conditional jump.
+func (op Opcode) Jump() bool {
+	return op == OpJumpIfTrue || op == OpJumpIfFalse
+}
+
+// A Mode tells how a parameter is interpreted.
+type Mode int
+
+const (
+	// The parameter is the address of the value.
+	ModePosition Mode = 0
+	// The parameter is the value.
+	ModeImmediate Mode = 1
+	// The parameter is the address of the value, relative to the relative base.
+	ModeRelative Mode = 2
+)
+
+// A Param is a parameter of an instruction.
+type Param struct {
+	Mode  Mode
+	Value int
+}
+
+// String returns the parameter in assembly syntax: "#4" for immediate values,
+// "[100]" for positions, and "[rb+3]" for relative positions.
+func (p Param) String() string {
+	switch p.Mode {
+	case ModeImmediate:
+		return fmt.Sprintf("#%d", p.Value)
+	case ModeRelative:
+		return fmt.Sprintf("[rb%+d]", p.Value)
+	default:
+		return fmt.Sprintf("[%d]", p.Value)
+	}
+}
+
+// An Instruction is a decoded instruction of an intcode program, or a region
+// of data that does not decode.
+type Instruction struct {
+	// Address of the instruction in the program.
+	Address int
+	Opcode  Opcode
+	Params  []Param
+	// Values of a data region, for which Opcode and Params are unset.
+	Data []int
+	// Whether a jump instruction of the program targets the address.
+	JumpTarget bool
+}
+
+// Len returns the number of values the instruction takes in the program.
+func (i Instruction) Len() int {
+	if i.Data != nil {
+		return len(i.Data)
+	}
+	return 1 + len(i.Params)
+}
+
+// Target returns the address an immediate jump instruction jumps to. ok is
+// false for other instructions, whose target is unknown until they run.
+func (i Instruction) Target() (target int, ok bool) {
+	if !i.Opcode.Jump() || i.Params[1].Mode != ModeImmediate {
+		return 0, false
+	}
+	return i.Params[1].Value, true
+}
+
+// String returns the instruction in assembly syntax, eg.
+// "ADD [rb+3], #4, [100]", or "DATA 1, 2, 3" for data regions.
+func (i Instruction) String() string {
+	return i.Format(nil)
+}
+
+// Format returns the instruction in assembly syntax, like String. Immediate
+// jump targets that are in labels are written as labels, eg. "JNZ [100], L0012".
+func (i Instruction) Format(labels map[int]bool) string {
+	if i.Data != nil {
+		values := make([]string, len(i.Data))
+		for j, v := range i.Data {
+			values[j] = fmt.Sprint(v)
+		}
+		return "DATA " + strings.Join(values, ", ")
+	}
+
+	params := make([]string, len(i.Params))
+	for j, p := range i.Params {
+		params[j] = p.String()
+	}
+	if target, ok := i.Target(); ok && labels[target] {
+		params[1] = Label(target)
+	}
+
+	if len(params) == 0 {
+		return i.Opcode.Mnemonic()
+	}
+	return i.Opcode.Mnemonic() + " " + strings.Join(params, ", ")
+}
+
+// Label returns the name of the label of an address in assembly listings.
+func Label(address int) string {
+	return fmt.Sprintf("L%04d", address)
+}
+
+// Decode decodes the instruction at address in program.
+func Decode(program []int, address int) (Instruction, error) {
+	if address < 0 || address >= len(program) {
+		return Instruction{}, fmt.Errorf("address %d out of program", address)
+	}
+
+	op, modes, err := decodeWord(program[address])
+	if err != nil {
+		return Instruction{}, fmt.Errorf("%w at address %d", err, address)
+	}
+	if address+op.Params() >= len(program) {
+		return Instruction{}, fmt.Errorf("truncated %s instruction at address %d", op.Mnemonic(), address)
+	}
+
+	instruction := Instruction{Address: address, Opcode: op}
+	for j := 0; j < op.Params(); j++ {
+		instruction.Params = append(instruction.Params, Param{Mode: modes[j], Value: program[address+1+j]})
+	}
+
+	return instruction, nil
+}
+
+// decodeWord decodes the first value of an instruction into its opcode and
+// the modes of its parameters.
+func decodeWord(value int) (Opcode, [3]Mode, error) {
+	var modes [3]Mode
+
+	op := Opcode(value % 100)
+	o, ok := opcodes[op]
+	if !ok || value < 0 {
+		return 0, modes, fmt.Errorf("unknown opcode %d", value)
+	}
+
+	rest := value / 100
+	for j := 0; j < o.params; j++ {
+		mode := Mode(rest % 10)
+		rest /= 10
+
+		if mode != ModePosition && mode != ModeImmediate && mode != ModeRelative {
+			return 0, modes, fmt.Errorf("unknown mode %d in instruction %d", mode, value)
+		}
+		if mode == ModeImmediate && j == o.write {
+			return 0, modes, fmt.Errorf("immediate mode for written parameter in instruction %d", value)
+		}
+		modes[j] = mode
+	}
+	if rest != 0 {
+		return 0, modes, fmt.Errorf("too many modes in instruction %d", value)
+	}
+
+	return op, modes, nil
+}
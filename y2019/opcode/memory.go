@@ -0,0 +1,153 @@
+package opcode
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+const (
+	// Memory is allocated in pages of 2^pageBits values.
+	pageBits = 10
+	pageSize = 1 << pageBits
+	pageMask = pageSize - 1
+	// Pages below this index are indexed by a slice, and the others by a map.
+	densePages = 1 << 16
+)
+
+// ErrInvalidAddress is returned when reading or writing memory at a negative
+// address.
+var ErrInvalidAddress = errors.New("invalid address")
+
+// memory is the sparse memory of an intcode program. Pages are allocated
+// when first written to; reading from a missing page gives zeros.
+type memory struct {
+	// Pages at low addresses, which may be nil.
+	pages [][]int
+	// Pages at high addresses.
+	far map[int][]int
+	// Indexes of the pages shared with copies of the memory, which are copied
+	// before being written to.
+	shared map[int]bool
+}
+
+// newMemory returns a memory holding program. The pages of the program share
+// its backing array, which must have a capacity of whole pages.
+func newMemory(program []int) *memory {
+	m := &memory{}
+	for address := 0; address < len(program); address += pageSize {
+		m.pages = append(m.pages, program[address:address+pageSize])
+	}
+	return m
+}
+
+// read returns the value at address.
+func (m *memory) read(address int) (int, error) {
+	if address < 0 {
+		return 0, fmt.Errorf("%w: %d", ErrInvalidAddress, address)
+	}
+
+	page := m.page(address>>pageBits, false)
+	if page == nil {
+		return 0, nil
+	}
+	return page[address&pageMask], nil
+}
+
+// write sets the value at address.
+func (m *memory) write(address, value int) error {
+	if address < 0 {
+		return fmt.Errorf("%w: %d", ErrInvalidAddress, address)
+	}
+
+	m.page(address>>pageBits, true)[address&pageMask] = value
+	return nil
+}
+
+// page returns the page with index p. If the page is missing, it is allocated
+// if alloc is true, and nil is returned otherwise.
+func (m *memory) page(p int, alloc bool) []int {
+	if p < len(m.pages) && m.pages[p] != nil {
+		if alloc && m.shared != nil && m.shared[p] {
+			m.pages[p] = m.unshare(p, m.pages[p])
+		}
+		return m.pages[p]
+	}
+	if !alloc {
+		if p < densePages {
+			return nil
+		}
+		return m.far[p]
+	}
+
+	if p < densePages {
+		for len(m.pages) <= p {
+			m.pages = append(m.pages, nil)
+		}
+		m.pages[p] = make([]int, pageSize)
+		return m.pages[p]
+	}
+
+	if m.far == nil {
+		m.far = make(map[int][]int)
+	}
+	if m.far[p] == nil {
+		m.far[p] = make([]int, pageSize)
+	} else if m.shared != nil && m.shared[p] {
+		m.far[p] = m.unshare(p, m.far[p])
+	}
+	return m.far[p]
+}
+
+// unshare returns a copy of the shared page with index p, which m owns.
+func (m *memory) unshare(p int, page []int) []int {
+	delete(m.shared, p)
+	return append(make([]int, 0, pageSize), page...)
+}
+
+// share adds the pages of m from index from on to dst. Both memories share
+// them until they write to them.
+func (m *memory) share(dst *memory, from int) {
+	mark := func(p int) {
+		if m.shared == nil {
+			m.shared = make(map[int]bool)
+		}
+		if dst.shared == nil {
+			dst.shared = make(map[int]bool)
+		}
+		m.shared[p], dst.shared[p] = true, true
+	}
+
+	for p := from; p < len(m.pages); p++ {
+		for len(dst.pages) <= p {
+			dst.pages = append(dst.pages, nil)
+		}
+		if m.pages[p] != nil {
+			dst.pages[p] = m.pages[p]
+			mark(p)
+		}
+	}
+	for p, page := range m.far {
+		if dst.far == nil {
+			dst.far = make(map[int][]int)
+		}
+		dst.far[p] = page
+		mark(p)
+	}
+}
+
+// indexes returns the indexes of the allocated pages of m, in order.
+func (m *memory) indexes() []int {
+	var indexes []int
+	for p, page := range m.pages {
+		if page != nil {
+			indexes = append(indexes, p)
+		}
+	}
+	far := make([]int, 0, len(m.far))
+	for p := range m.far {
+		far = append(far, p)
+	}
+	sort.Ints(far)
+	return append(indexes, far...)
+}
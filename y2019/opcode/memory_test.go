@@ -0,0 +1,156 @@
+package opcode
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestMemory(t *testing.T) {
+	intcode := Intcode{Program: []int{99}}
+
+	if err := intcode.SetValue(1e9, 42); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value, err := intcode.GetValue(1e9); err != nil || value != 42 {
+		t.Errorf("expected 42 at address 1e9, got %d (%v)", value, err)
+	}
+	if value, err := intcode.GetValue(1e9 + 1); err != nil || value != 0 {
+		t.Errorf("expected 0 at address 1e9+1, got %d (%v)", value, err)
+	}
+	if len(intcode.Program) != 1 {
+		t.Errorf("expected program to keep its length, got %d", len(intcode.Program))
+	}
+
+	if _, err := intcode.GetValue(-1); !errors.Is(err, ErrInvalidAddress) {
+		t.Errorf("expected %v reading address -1, got %v", ErrInvalidAddress, err)
+	}
+	if err := intcode.SetValue(-1, 0); !errors.Is(err, ErrInvalidAddress) {
+		t.Errorf("expected %v writing address -1, got %v", ErrInvalidAddress, err)
+	}
+}
+
+func TestFaults(t *testing.T) {
+	tests := map[string][]int{
+		"negative read":   {4, -1, 99},
+		"negative write":  {1101, 1, 1, -1, 99},
+		"relative write":  {109, -5, 21101, 1, 1, 0, 99},
+		"immediate write": {11101, 1, 1, 0, 99},
+		"unknown mode":    {304, 0, 99},
+		"unknown opcode":  {42},
+	}
+
+	for name, program := range tests {
+		t.Run(name, func(t *testing.T) {
+			intcode := Intcode{Program: program}
+			if _, err := intcode.RunIntcode(); err == nil {
+				t.Error("expected error")
+			}
+			if intcode.State != StateFaulted {
+				t.Errorf("expected program to be %s, got %s", StateFaulted, intcode.State)
+			}
+		})
+	}
+}
+
+func TestSelfModifyingProgram(t *testing.T) {
+	// The first instruction runs twice: the second time, it was turned from an
+	// addition into a multiplication.
+	program, err := Assemble(`
+start:	ADD [x], [x], [x]
+	JNZ [done], end
+	ADD #1, #0, [done]
+	ADD #2, #0, [start]
+	JNZ #1, start
+end:	OUT [x]
+	HLT
+x:	DATA 3
+done:	DATA 0
+`)
+	if err != nil {
+		t.Fatalf("could not assemble: %v", err)
+	}
+
+	intcode := Intcode{Program: program}
+	outputs, err := intcode.RunIntcode()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 3 + 3 = 6, then 6 * 6 = 36.
+	if len(outputs) != 1 || outputs[0] != 36 {
+		t.Errorf("expected outputs [36], got %v", outputs)
+	}
+	// The changes of the program to itself are visible in Program.
+	if intcode.Program[0] != int(OpMultiply) {
+		t.Errorf("expected program to start with %d, got %d", OpMultiply, intcode.Program[0])
+	}
+}
+
+// BenchmarkBoost runs the BOOST program of day 9 in sensor boost mode.
+func BenchmarkBoost(b *testing.B) {
+	content, err := os.ReadFile("../d09/fabienz/testdata/input.txt")
+	if err != nil {
+		b.Skipf("could not read BOOST program: %v", err)
+	}
+
+	var program []int
+	for _, field := range strings.Split(strings.TrimSpace(string(content)), ",") {
+		value, err := strconv.Atoi(field)
+		if err != nil {
+			b.Fatalf("could not parse BOOST program: %v", err)
+		}
+		program = append(program, value)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		intcode := Intcode{Program: program, Inputs: []int{2}}
+		if _, err := intcode.RunIntcode(); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestWriteAfterProgram(t *testing.T) {
+	// The last instruction reads its operand beyond the end of the program.
+	intcode := Intcode{Program: []int{1105, 1, 10, 1101, 0, 42, 11, 1105, 1, 10, 104}}
+	for i := 0; i < 2; i++ {
+		if err := intcode.ComputeStep(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// Changing the operand must change the output of the instruction, once
+	// decoded.
+	if err := intcode.SetValue(11, 42); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	intcode.Pos = 10
+	if err := intcode.ComputeStep(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []int{0, 42}; !reflect.DeepEqual(intcode.Outputs, want) {
+		t.Errorf("expected outputs %v, got %v", want, intcode.Outputs)
+	}
+}
+
+func TestInitAfterDecode(t *testing.T) {
+	// Adds the values at the addresses given by the noun and verb.
+	intcode := Intcode{Program: []int{1, 0, 0, 0, 99, 10, 20}}
+	if _, err := intcode.Instruction(0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := intcode.InitIntcode(5, 6); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := intcode.RunIntcode(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if intcode.Program[0] != 30 {
+		t.Errorf("expected the initialized instruction to compute 30, got %d", intcode.Program[0])
+	}
+}
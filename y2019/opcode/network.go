@@ -0,0 +1,215 @@
+package opcode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// NATAddress is the address of the NAT of a network.
+const NATAddress = 255
+
+// idleRounds is the number of rounds without packets after which a network is
+// idle.
+const idleRounds = 2
+
+// ErrStop is returned by a NAT to stop the network it is part of.
+var ErrStop = errors.New("network stopped")
+
+// A Packet is sent by a computer of a network to another one.
+type Packet struct {
+	Dest, X, Y int
+}
+
+// A NAT is a device at NATAddress, that watches a network and wakes it up
+// when it is idle. Its methods return ErrStop to stop the network.
+type NAT interface {
+	// Receive handles a packet sent to NATAddress.
+	Receive(p Packet) error
+	// Wake returns the packets to send when the network is idle.
+	Wake() ([]Packet, error)
+}
+
+// A Network of intcode computers that send packets to each other. Computers
+// read their address as their first input. Then, they read the X and Y values
+// of the packets they receive, or -1 when there is none, and output the
+// address, X and Y values of the packets they send.
+//
+// The network runs in rounds. In each round, all computers run at the same
+// time until they wait for an input, then the packets they sent are delivered.
+type Network struct {
+	Computers []*Intcode
+	// Optional device at NATAddress.
+	NAT NAT
+
+	queues [][]Packet
+	idle   int
+}
+
+// NewNetwork returns a network of n computers running program, with addresses
+// 0 to n-1.
+func NewNetwork(program []int, n int) *Network {
+	network := &Network{queues: make([][]Packet, n)}
+	for address := 0; address < n; address++ {
+		network.Computers = append(network.Computers, &Intcode{Program: program, Inputs: []int{address}})
+	}
+	return network
+}
+
+// Send queues p for its destination.
+func (n *Network) Send(p Packet) error {
+	if p.Dest == NATAddress && n.NAT != nil {
+		return n.NAT.Receive(p)
+	}
+	if p.Dest < 0 || p.Dest >= len(n.Computers) {
+		return fmt.Errorf("no computer at address %d", p.Dest)
+	}
+
+	n.queues[p.Dest] = append(n.queues[p.Dest], p)
+	return nil
+}
+
+// Idle reports whether the computers of the network have been waiting for
+// packets, without sending any, for a few rounds.
+func (n *Network) Idle() bool {
+	return n.idle >= idleRounds
+}
+
+// Step runs a round: computers receive the packets queued for them, and run
+// until they wait for an input. Then, the packets they sent are queued. Step
+// returns ErrStop if the NAT stops the network when receiving a packet.
+func (n *Network) Step() error {
+	if len(n.queues) < len(n.Computers) {
+		n.queues = append(n.queues, make([][]Packet, len(n.Computers)-len(n.queues))...)
+	}
+
+	received := false
+	for address, computer := range n.Computers {
+		for _, p := range n.queues[address] {
+			computer.Inputs = append(computer.Inputs, p.X, p.Y)
+			received = true
+		}
+		n.queues[address] = n.queues[address][:0]
+		if len(computer.Inputs) == 0 {
+			computer.Inputs = append(computer.Inputs, -1)
+		}
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(n.Computers))
+	for address, computer := range n.Computers {
+		wg.Add(1)
+		go func(address int, computer *Intcode) {
+			defer wg.Done()
+			if _, err := computer.RunIntcode(); err != nil {
+				errs[address] = fmt.Errorf("computer %d: %w", address, err)
+			} else if computer.State == StateHalted {
+				errs[address] = fmt.Errorf("computer %d halted", address)
+			}
+		}(address, computer)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	// Deliver packets in the order of the addresses of their senders.
+	sent := false
+	for _, computer := range n.Computers {
+		outputs := computer.Outputs
+		for ; len(outputs) >= 3; outputs = outputs[3:] {
+			sent = true
+			if err := n.Send(Packet{Dest: outputs[0], X: outputs[1], Y: outputs[2]}); err != nil {
+				computer.Outputs = append(computer.Outputs[:0], outputs[3:]...)
+				return err
+			}
+		}
+		// Keep packets that are not fully sent yet.
+		computer.Outputs = append(computer.Outputs[:0], outputs...)
+	}
+
+	if received || sent {
+		n.idle = 0
+	} else {
+		n.idle++
+	}
+	return nil
+}
+
+// Run runs the network until the NAT stops it, in which case it returns nil.
+// When the network is idle, the NAT is woken up.
+//
+// To stop at the first packet sent to the NAT instead, call Step until the
+// NAT receives it.
+func (n *Network) Run(ctx context.Context) error {
+	err := n.run(ctx)
+	if errors.Is(err, ErrStop) {
+		return nil
+	}
+	return err
+}
+
+func (n *Network) run(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := n.Step(); err != nil {
+			return err
+		}
+		if !n.Idle() {
+			continue
+		}
+
+		if n.NAT == nil {
+			return errors.New("network is idle, and there is no NAT to wake it up")
+		}
+		packets, err := n.NAT.Wake()
+		if err != nil {
+			return err
+		}
+		if len(packets) == 0 {
+			return errors.New("network is idle, and the NAT did not wake it up")
+		}
+		for _, p := range packets {
+			if err := n.Send(p); err != nil {
+				return err
+			}
+		}
+		n.idle = 0
+	}
+}
+
+// A RelayNAT is the NAT of day 23. It remembers the last packet it received,
+// and sends it to address 0 when the network is idle. It stops the network
+// when it sends the same Y value twice in a row.
+type RelayNAT struct {
+	// Packets the NAT received.
+	Received []Packet
+	// Y values of the packets the NAT sent.
+	Sent []int
+}
+
+// Receive remembers p.
+func (nat *RelayNAT) Receive(p Packet) error {
+	nat.Received = append(nat.Received, p)
+	return nil
+}
+
+// Wake sends the last packet received to address 0.
+func (nat *RelayNAT) Wake() ([]Packet, error) {
+	if len(nat.Received) == 0 {
+		return nil, nil
+	}
+
+	p := nat.Received[len(nat.Received)-1]
+	p.Dest = 0
+	if len(nat.Sent) > 0 && nat.Sent[len(nat.Sent)-1] == p.Y {
+		return nil, ErrStop
+	}
+	nat.Sent = append(nat.Sent, p.Y)
+	return []Packet{p}, nil
+}
@@ -0,0 +1,87 @@
+package opcode
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+// relay forwards the packets it receives to the next computer of a network of
+// 3, with X incremented. The last computer sends them to the NAT.
+const relay = `
+	IN [addr]
+	ADD [addr], #1, [dest]
+	EQ [dest], #3, [last]
+	JZ [last], loop
+	ADD #255, #0, [dest]
+loop:	IN [x]
+	EQ [x], #-1, [empty]
+	JNZ [empty], loop
+	IN [y]
+	ADD [x], #1, [x]
+	OUT [dest]
+	OUT [x]
+	OUT [y]
+	JNZ #1, loop
+addr:	DATA 0
+dest:	DATA 0
+last:	DATA 0
+x:	DATA 0
+y:	DATA 0
+empty:	DATA 0
+`
+
+func TestNetwork(t *testing.T) {
+	program, err := Assemble(relay)
+	if err != nil {
+		t.Fatalf("could not assemble: %v", err)
+	}
+
+	network := NewNetwork(program, 3)
+	nat := &RelayNAT{}
+	network.NAT = nat
+	if err := network.Send(Packet{Dest: 0, X: 1, Y: 100}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rounds := 0
+	for len(nat.Received) == 0 {
+		if err := network.Step(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		rounds++
+	}
+	if rounds != 3 || nat.Received[0] != (Packet{Dest: NATAddress, X: 4, Y: 100}) {
+		t.Errorf("expected NAT to receive {255 4 100} after 3 rounds, got %v after %d", nat.Received[0], rounds)
+	}
+
+	if err := network.Run(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmt.Sprint(nat.Received) != "[{255 4 100} {255 7 100}]" {
+		t.Errorf("expected NAT to receive 2 packets, got %v", nat.Received)
+	}
+	if fmt.Sprint(nat.Sent) != "[100]" {
+		t.Errorf("expected NAT to send Y 100 once before stopping, got %v", nat.Sent)
+	}
+}
+
+func TestNetworkErrors(t *testing.T) {
+	program, err := Assemble(relay)
+	if err != nil {
+		t.Fatalf("could not assemble: %v", err)
+	}
+
+	network := NewNetwork(program, 3)
+	if err := network.Send(Packet{Dest: 3}); err == nil {
+		t.Error("expected error sending to a missing computer")
+	}
+	if err := network.Run(context.Background()); err == nil {
+		t.Error("expected error when the network is idle without a NAT")
+	}
+
+	halting := NewNetwork([]int{3, 0, 99}, 2)
+	if err := halting.Step(); err == nil {
+		t.Error("expected error when a computer halts")
+	}
+}
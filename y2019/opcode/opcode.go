@@ -1,121 +1,300 @@
 package opcode
 
 import (
+	"context"
+	"errors"
 	"fmt"
 )
 
+// ErrInputClosed is returned by Run when the program needs an input but the
+// input channel is closed.
+var ErrInputClosed = errors.New("input channel closed")
+
+// State of an intcode program.
+type State int
+
+const (
+	// The program is running, or ready to run.
+	StateRunning State = iota
+	// The program is paused on an input instruction, waiting for an input.
+	StateAwaitingInput
+	// The program reached a halt instruction.
+	StateHalted
+	// The program stopped on an error.
+	StateFaulted
+)
+
+var stateNames = map[State]string{
+	StateRunning:       "running",
+	StateAwaitingInput: "awaiting input",
+	StateHalted:        "halted",
+	StateFaulted:       "faulted",
+}
+
+func (s State) String() string {
+	if name, ok := stateNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("State(%d)", int(s))
+}
+
+// An InputFunc provides the next input of an intcode program. It is called
+// when the program needs an input and there are none left in Inputs.
+type InputFunc func() (int, error)
+
+// An OutputFunc receives the outputs of an intcode program, instead of them
+// being appended to Outputs.
+type OutputFunc func(int) error
+
 // Structure of the intcode program: instructions, inputs and outputs.
+//
+// Program holds the program when it is loaded, and reflects the changes the
+// program makes to its own instructions as it runs. Memory beyond the end of
+// the program is sparse: it is allocated as the program writes to it, and is
+// only accessible with GetValue and SetValue. Once the program started, modify
+// its memory with SetValue.
 type Intcode struct {
 	Program      []int
 	Inputs       []int
 	Outputs      []int
 	Pos          int
-	Halted       bool
+	State        State
 	RelativeBase int
+	// Optional source of inputs, once Inputs is empty.
+	Input InputFunc
+	// Optional destination of outputs, instead of Outputs.
+	Output OutputFunc
+	// Optional hook called with each instruction the program runs.
+	Trace TraceFunc
+
+	memory *memory
+	// Decoded instructions of the program, by address.
+	cache []cached
+}
+
+// A cached instruction, decoded once and run many times.
+type cached struct {
+	op     Opcode
+	modes  [3]Mode
+	params [3]int
+	ok     bool
 }
 
 // Compute a step of the intcode program
-func (intcode *Intcode) ComputeStep() (err error) {
-	// Get the instructions
+func (intcode *Intcode) ComputeStep() error {
+	if intcode.memory == nil {
+		intcode.load()
+	}
+	if intcode.Trace != nil {
+		return intcode.traceStep()
+	}
+	return intcode.compute()
+}
+
+// compute runs the instruction at the current position.
+func (intcode *Intcode) compute() error {
 	pos := intcode.Pos
+	in, err := intcode.decode(pos)
+	if err != nil {
+		return intcode.fault(err)
+	}
+
+	switch in.op {
+	case OpAdd, OpMultiply, OpLessThan, OpEquals:
+		a, err := intcode.param(&in, 0)
+		if err != nil {
+			return intcode.fault(err)
+		}
+		b, err := intcode.param(&in, 1)
+		if err != nil {
+			return intcode.fault(err)
+		}
 
-	// Parse the instruction and the parameters depending on the parameters mode
-	opcode := intcode.GetValue(pos) % 100
-	param1Mode := (intcode.GetValue(pos) / 100) % 10
-	param2Mode := (intcode.GetValue(pos) / 1000) % 10
-	param3Mode := (intcode.GetValue(pos) / 10000) % 10
+		var result int
+		switch in.op {
+		case OpAdd:
+			result = a + b
+		case OpMultiply:
+			result = a * b
+		case OpLessThan:
+			if a < b {
+				result = 1
+			}
+		case OpEquals:
+			if a == b {
+				result = 1
+			}
+		}
 
-	switch opcode {
-	case 1: // Add
-		intcode.SetValue(intcode.getParamAddress(pos+3, param3Mode), intcode.GetValue(intcode.getParamAddress(pos+1, param1Mode))+intcode.GetValue(intcode.getParamAddress(pos+2, param2Mode)))
-		intcode.Pos = pos + 4
-		return nil
-	case 2: // Multiply
-		intcode.SetValue(intcode.getParamAddress(pos+3, param3Mode), intcode.GetValue(intcode.getParamAddress(pos+1, param1Mode))*intcode.GetValue(intcode.getParamAddress(pos+2, param2Mode)))
+		if err := intcode.SetValue(intcode.address(&in, 2), result); err != nil {
+			return intcode.fault(err)
+		}
 		intcode.Pos = pos + 4
 		return nil
-	case 3: // Input
-		if len(intcode.Inputs) == 0 {
-			// Save the position of the instruction in the program
-			intcode.Pos = pos
-			intcode.Halted = true
+	case OpInput:
+		var value int
+		switch {
+		case len(intcode.Inputs) > 0:
+			value = intcode.Inputs[0]
+			intcode.Inputs = intcode.Inputs[1:]
+		case intcode.Input != nil:
+			if value, err = intcode.Input(); err != nil {
+				return intcode.fault(fmt.Errorf("could not read input: %w", err))
+			}
+		default:
+			// Stay on the instruction until an input is available
+			intcode.State = StateAwaitingInput
 			return nil
 		}
-		intcode.SetValue(intcode.getParamAddress(pos+1, param1Mode), intcode.Inputs[0])
-		intcode.Inputs = intcode.Inputs[1:]
+		if err := intcode.SetValue(intcode.address(&in, 0), value); err != nil {
+			return intcode.fault(err)
+		}
 		intcode.Pos = pos + 2
+		intcode.State = StateRunning
 		return nil
-	case 4: // Output
-		intcode.Outputs = append(intcode.Outputs, intcode.GetValue(intcode.getParamAddress(pos+1, param1Mode)))
+	case OpOutput:
+		value, err := intcode.param(&in, 0)
+		if err != nil {
+			return intcode.fault(err)
+		}
+		if intcode.Output == nil {
+			intcode.Outputs = append(intcode.Outputs, value)
+		} else if err := intcode.Output(value); err != nil {
+			return intcode.fault(fmt.Errorf("could not write output: %w", err))
+		}
 		intcode.Pos = pos + 2
 		return nil
-	case 5: // Jump if true
-		if intcode.GetValue(intcode.getParamAddress(pos+1, param1Mode)) != 0 {
-			intcode.Pos = intcode.GetValue(intcode.getParamAddress(pos+2, param2Mode))
-			return nil
+	case OpJumpIfTrue, OpJumpIfFalse:
+		condition, err := intcode.param(&in, 0)
+		if err != nil {
+			return intcode.fault(err)
 		}
-		intcode.Pos = pos + 3
-		return nil
-	case 6: // Jump if false
-		if intcode.GetValue(intcode.getParamAddress(pos+1, param1Mode)) == 0 {
-			intcode.Pos = intcode.GetValue(intcode.getParamAddress(pos+2, param2Mode))
+		if (condition != 0) == (in.op == OpJumpIfTrue) {
+			target, err := intcode.param(&in, 1)
+			if err != nil {
+				return intcode.fault(err)
+			}
+			intcode.Pos = target
 			return nil
 		}
 		intcode.Pos = pos + 3
 		return nil
-	case 7: // Less than
-		if intcode.GetValue(intcode.getParamAddress(pos+1, param1Mode)) < intcode.GetValue(intcode.getParamAddress(pos+2, param2Mode)) {
-			intcode.SetValue(intcode.getParamAddress(pos+3, param3Mode), 1)
-		} else {
-			intcode.SetValue(intcode.getParamAddress(pos+3, param3Mode), 0)
-		}
-		intcode.Pos = pos + 4
-		return nil
-	case 8: // Equals
-		if intcode.GetValue(intcode.getParamAddress(pos+1, param1Mode)) == intcode.GetValue(intcode.getParamAddress(pos+2, param2Mode)) {
-			intcode.SetValue(intcode.getParamAddress(pos+3, param3Mode), 1)
-		} else {
-			intcode.SetValue(intcode.getParamAddress(pos+3, param3Mode), 0)
+	case OpAdjustRelativeBase:
+		offset, err := intcode.param(&in, 0)
+		if err != nil {
+			return intcode.fault(err)
 		}
-		intcode.Pos = pos + 4
-		return nil
-	case 9: // Adjust relative base
-		intcode.RelativeBase += intcode.GetValue(intcode.getParamAddress(pos+1, param1Mode))
+		intcode.RelativeBase += offset
 		intcode.Pos = pos + 2
 		return nil
-	case 99:
-		intcode.Pos = -1
-		intcode.Halted = true
+	default: // OpHalt
+		intcode.State = StateHalted
 		return nil
+	}
+}
+
+// fault stops the program on err.
+func (intcode *Intcode) fault(err error) error {
+	intcode.State = StateFaulted
+	return fmt.Errorf("%w at position %d", err, intcode.Pos)
+}
+
+// load copies the program into memory, so that the program can run.
+func (intcode *Intcode) load() {
+	// The program is copied, so that programs made from the same slice do not
+	// share memory, and so that its last page is whole.
+	pages := (len(intcode.Program) + pageSize - 1) / pageSize
+	program := make([]int, len(intcode.Program), pages*pageSize)
+	copy(program, intcode.Program)
+
+	intcode.Program = program
+	intcode.memory = newMemory(program[:cap(program)])
+	intcode.cache = make([]cached, len(program))
+}
+
+// decode returns the instruction at pos, from the cache if possible.
+func (intcode *Intcode) decode(pos int) (cached, error) {
+	if pos >= 0 && pos < len(intcode.cache) && intcode.cache[pos].ok {
+		return intcode.cache[pos], nil
+	}
+
+	word, err := intcode.memory.read(pos)
+	if err != nil {
+		return cached{}, err
+	}
+
+	var in cached
+	if in.op, in.modes, err = decodeWord(word); err != nil {
+		return cached{}, err
+	}
+	for j := 0; j < in.op.Params(); j++ {
+		if in.params[j], err = intcode.memory.read(pos + 1 + j); err != nil {
+			return cached{}, err
+		}
+	}
+
+	if pos < len(intcode.cache) {
+		in.ok = true
+		intcode.cache[pos] = in
+	}
+	return in, nil
+}
+
+// param returns the value of the parameter j of an instruction.
+func (intcode *Intcode) param(in *cached, j int) (int, error) {
+	switch in.modes[j] {
+	case ModeImmediate:
+		return in.params[j], nil
+	case ModeRelative:
+		return intcode.memory.read(intcode.RelativeBase + in.params[j])
 	default:
-		intcode.Pos = -1
-		intcode.Halted = true
-		return fmt.Errorf("unknown opcode %d", opcode)
+		return intcode.memory.read(in.params[j])
 	}
 }
 
-// Init the intcode program with the given noun and verb
-func (intcode *Intcode) InitIntcode(noun int, verb int) {
-	intcode.Program[1] = noun
-	intcode.Program[2] = verb
+// address returns the address the parameter j of an instruction points to.
+func (intcode *Intcode) address(in *cached, j int) int {
+	if in.modes[j] == ModeRelative {
+		return intcode.RelativeBase + in.params[j]
+	}
+	return in.params[j]
+}
+
+// Instruction returns the instruction at pos in memory.
+func (intcode *Intcode) Instruction(pos int) (Instruction, error) {
+	if intcode.memory == nil {
+		intcode.load()
+	}
+	in, err := intcode.decode(pos)
+	if err != nil {
+		return Instruction{}, fmt.Errorf("%w at address %d", err, pos)
+	}
+
+	instruction := Instruction{Address: pos, Opcode: in.op}
+	for j := 0; j < in.op.Params(); j++ {
+		instruction.Params = append(instruction.Params, Param{Mode: in.modes[j], Value: in.params[j]})
+	}
+	return instruction, nil
 }
 
-// Get a parameter value depending on the parameter mode
-func (intcode Intcode) getParamAddress(pos int, mode int) int {
-	switch mode {
-	case 0:
-		return intcode.GetValue(pos)
-	case 2:
-		return intcode.RelativeBase + intcode.GetValue(pos)
-	default: // default is mode 1
-		return pos
+// Init the intcode program with the given noun and verb
+func (intcode *Intcode) InitIntcode(noun int, verb int) error {
+	if err := intcode.SetValue(1, noun); err != nil {
+		return err
 	}
+	return intcode.SetValue(2, verb)
 }
 
-// Run the intcode program
+// Run the intcode program until it halts, faults, or waits for an input that
+// is not available yet. In that last case, add inputs and call RunIntcode again
+// to resume the program.
 func (intcode *Intcode) RunIntcode() ([]int, error) {
-	intcode.Halted = false
-	for !intcode.Halted {
+	if intcode.Done() {
+		return intcode.Outputs, nil
+	}
+
+	intcode.State = StateRunning
+	for intcode.State == StateRunning {
 		err := intcode.ComputeStep()
 		if err != nil {
 			return intcode.Outputs, err
@@ -124,28 +303,88 @@ func (intcode *Intcode) RunIntcode() ([]int, error) {
 	return intcode.Outputs, nil
 }
 
-// Getter of a value in the intcode program at a given position. Adds 0s if the position is out of the program.
-func (intcode Intcode) GetValue(pos int) int {
-	if pos < 0 {
-		panic(fmt.Sprintf("trying to get value at negative position {%d}", pos))
+// Done reports whether the program halted or faulted, and cannot run anymore.
+func (intcode *Intcode) Done() bool {
+	return intcode.State == StateHalted || intcode.State == StateFaulted
+}
+
+// Run the intcode program until it halts, reading inputs from in once Inputs
+// is empty and sending outputs to out. Out is closed when Run returns, so that
+// programs can be chained in pipelines, each running in its own goroutine.
+//
+// While it runs, the channels replace the Input and Output functions of the
+// program, which are restored when Run returns.
+//
+// Run returns early with an error if ctx is done, if the program faults, or if
+// it needs an input while in is closed.
+func (intcode *Intcode) Run(ctx context.Context, in <-chan int, out chan<- int) error {
+	defer close(out)
+
+	input, output := intcode.Input, intcode.Output
+	defer func() { intcode.Input, intcode.Output = input, output }()
+
+	intcode.Input = func() (int, error) {
+		select {
+		case value, ok := <-in:
+			if !ok {
+				return 0, ErrInputClosed
+			}
+			return value, nil
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
 	}
-	if pos < len(intcode.Program) {
-		return intcode.Program[pos]
+	intcode.Output = func(value int) error {
+		select {
+		case out <- value:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
 	}
-	return 0
+
+	if intcode.Done() {
+		return nil
+	}
+
+	intcode.State = StateRunning
+	for steps := 0; intcode.State == StateRunning; steps++ {
+		// Programs may loop for long without any input or output.
+		if steps%1024 == 0 && ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := intcode.ComputeStep(); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-// Setter of a value in the intcode program at a given position. Adds 0s if the position is out of the program.
-func (intcode *Intcode) SetValue(pos int, value int) {
-	if pos < 0 {
-		panic(fmt.Sprintf("trying to set value {%d} at negative position {%d}", value, pos))
+// Getter of a value in the memory of the intcode program at a given position.
+// Memory that was never written to holds 0s.
+func (intcode *Intcode) GetValue(pos int) (int, error) {
+	if intcode.memory == nil {
+		intcode.load()
 	}
-	if pos < len(intcode.Program) {
-		intcode.Program[pos] = value
-		return
+	return intcode.memory.read(pos)
+}
+
+// Setter of a value in the memory of the intcode program at a given position.
+func (intcode *Intcode) SetValue(pos int, value int) error {
+	if intcode.memory == nil {
+		intcode.load()
+	}
+	if err := intcode.memory.write(pos, value); err != nil {
+		return err
 	}
-	for i := len(intcode.Program); i < pos; i++ {
-		intcode.Program = append(intcode.Program, 0)
+
+	// Forget the instructions that include the value. The last instructions
+	// of the program may read operands beyond its end.
+	for p := pos - 3; p <= pos && p < len(intcode.cache); p++ {
+		if p >= 0 {
+			intcode.cache[p].ok = false
+		}
 	}
-	intcode.Program = append(intcode.Program, value)
+	return nil
 }
@@ -0,0 +1,146 @@
+package opcode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// echo outputs its input, then halts.
+var echo = []int{3, 0, 4, 0, 99}
+
+func TestRunIntcodeStates(t *testing.T) {
+	intcode := Intcode{Program: append([]int(nil), echo...)}
+
+	if _, err := intcode.RunIntcode(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if intcode.State != StateAwaitingInput {
+		t.Fatalf("expected program to be %s, got %s", StateAwaitingInput, intcode.State)
+	}
+
+	intcode.Inputs = append(intcode.Inputs, 42)
+	outputs, err := intcode.RunIntcode()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if intcode.State != StateHalted {
+		t.Fatalf("expected program to be %s, got %s", StateHalted, intcode.State)
+	}
+	if fmt.Sprint(outputs) != "[42]" {
+		t.Errorf("expected outputs [42], got %v", outputs)
+	}
+
+	faulty := Intcode{Program: []int{42}}
+	if _, err := faulty.RunIntcode(); err == nil {
+		t.Error("expected error for unknown opcode")
+	}
+	if faulty.State != StateFaulted {
+		t.Errorf("expected program to be %s, got %s", StateFaulted, faulty.State)
+	}
+}
+
+func TestInputOutputFuncs(t *testing.T) {
+	var outputs []int
+	intcode := Intcode{
+		Program: append([]int(nil), echo...),
+		Input:   func() (int, error) { return 7, nil },
+		Output:  func(value int) error { outputs = append(outputs, value); return nil },
+	}
+
+	if _, err := intcode.RunIntcode(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmt.Sprint(outputs) != "[7]" || len(intcode.Outputs) != 0 {
+		t.Errorf("expected outputs [7] to be sent to OutputFunc, got %v and %v", outputs, intcode.Outputs)
+	}
+}
+
+func TestRun(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := map[string]struct {
+		ctx    context.Context
+		inputs []int
+		// Whether to close the input channel before running.
+		closed bool
+		err    error
+	}{
+		"input": {
+			ctx:    context.Background(),
+			inputs: []int{3},
+		},
+		"closed input": {
+			ctx:    context.Background(),
+			closed: true,
+			err:    ErrInputClosed,
+		},
+		"canceled": {
+			ctx: canceled,
+			err: context.Canceled,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Chain two programs in a pipeline.
+			in, link, out := make(chan int, len(test.inputs)), make(chan int), make(chan int)
+			for _, input := range test.inputs {
+				in <- input
+			}
+			if test.closed {
+				close(in)
+			}
+
+			first := Intcode{Program: append([]int(nil), echo...)}
+			second := Intcode{Program: append([]int(nil), echo...)}
+
+			errs := make(chan error, 2)
+			go func() { errs <- first.Run(test.ctx, in, link) }()
+			go func() { errs <- second.Run(test.ctx, link, out) }()
+
+			var outputs []int
+			for value := range out {
+				outputs = append(outputs, value)
+			}
+
+			for i := 0; i < 2; i++ {
+				if err := <-errs; err != nil && !errors.Is(err, test.err) {
+					t.Errorf("expected error %v, got %v", test.err, err)
+				} else if err == nil && test.err != nil {
+					t.Errorf("expected error %v, got none", test.err)
+				}
+			}
+
+			if fmt.Sprint(outputs) != fmt.Sprint(test.inputs) && test.err == nil {
+				t.Errorf("expected outputs %v, got %v", test.inputs, outputs)
+			}
+		})
+	}
+}
+
+func TestRunRestoresHooks(t *testing.T) {
+	var outputs []int
+	intcode := Intcode{
+		Program: append([]int(nil), echo...),
+		Output:  func(value int) error { outputs = append(outputs, value); return nil },
+	}
+
+	in, out := make(chan int, 1), make(chan int, 1)
+	in <- 5
+	if err := intcode.Run(context.Background(), in, out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value := <-out; value != 5 {
+		t.Errorf("expected output 5 on the channel, got %d", value)
+	}
+
+	if intcode.Input != nil || intcode.Output == nil {
+		t.Fatal("expected the hooks of the program to be restored")
+	}
+	if err := intcode.Output(7); err != nil || fmt.Sprint(outputs) != "[7]" {
+		t.Errorf("expected the restored Output to be called, got %v", outputs)
+	}
+}
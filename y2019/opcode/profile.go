@@ -0,0 +1,110 @@
+package opcode
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+)
+
+// A Profiler counts the instructions an intcode program runs. Set its Trace
+// method as the TraceFunc of the program.
+type Profiler struct {
+	// Number of instructions run.
+	Steps int
+	// Number of instructions run, by address and by opcode.
+	Hits    map[int]int
+	Opcodes map[Opcode]int
+}
+
+// NewProfiler returns an empty profiler.
+func NewProfiler() *Profiler {
+	return &Profiler{
+		Hits:    make(map[int]int),
+		Opcodes: make(map[Opcode]int),
+	}
+}
+
+// Trace counts s.
+func (p *Profiler) Trace(s Step) {
+	p.Steps++
+	p.Hits[s.Instruction.Address]++
+	p.Opcodes[s.Instruction.Opcode]++
+}
+
+// Hotspots returns the n addresses of the instructions that ran the most, by
+// decreasing number of runs.
+func (p *Profiler) Hotspots(n int) []int {
+	addresses := make([]int, 0, len(p.Hits))
+	for address := range p.Hits {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		a, b := addresses[i], addresses[j]
+		if p.Hits[a] != p.Hits[b] {
+			return p.Hits[a] > p.Hits[b]
+		}
+		return a < b
+	})
+
+	if n < len(addresses) {
+		addresses = addresses[:n]
+	}
+	return addresses
+}
+
+// WriteReport writes the number of runs of each opcode, then the disassembly
+// of program with the number of runs of each instruction. Lines of data that
+// ran, because the program modified itself, are counted too.
+func (p *Profiler) WriteReport(w io.Writer, program []int) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', tabwriter.AlignRight)
+
+	ops := make([]Opcode, 0, len(p.Opcodes))
+	for op := range p.Opcodes {
+		ops = append(ops, op)
+	}
+	sort.Slice(ops, func(i, j int) bool {
+		if p.Opcodes[ops[i]] != p.Opcodes[ops[j]] {
+			return p.Opcodes[ops[i]] > p.Opcodes[ops[j]]
+		}
+		return ops[i] < ops[j]
+	})
+
+	fmt.Fprintf(tw, "Opcode\tRuns\tShare\t\n")
+	for _, op := range ops {
+		fmt.Fprintf(tw, "%s\t%d\t%s\t\n", op.Mnemonic(), p.Opcodes[op], p.share(p.Opcodes[op]))
+	}
+	fmt.Fprintf(tw, "Total\t%d\t\t\n", p.Steps)
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintln(w)
+
+	instructions := Disassemble(program)
+	labels := Labels(instructions)
+	fmt.Fprintf(tw, "Runs\tShare\t\tListing\n")
+	for _, instruction := range instructions {
+		if instruction.JumpTarget {
+			fmt.Fprintf(tw, "\t\t\t%s:\n", Label(instruction.Address))
+		}
+
+		runs := 0
+		for address := instruction.Address; address < instruction.Address+instruction.Len(); address++ {
+			runs += p.Hits[address]
+		}
+		if runs == 0 {
+			fmt.Fprintf(tw, "\t\t\t%04d: %s\n", instruction.Address, instruction.Format(labels))
+			continue
+		}
+		fmt.Fprintf(tw, "%d\t%s\t\t%04d: %s\n", runs, p.share(runs), instruction.Address, instruction.Format(labels))
+	}
+	return tw.Flush()
+}
+
+// share returns runs as a percentage of all the instructions run.
+func (p *Profiler) share(runs int) string {
+	if p.Steps == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", 100*float64(runs)/float64(p.Steps))
+}
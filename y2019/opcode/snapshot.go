@@ -0,0 +1,175 @@
+package opcode
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+)
+
+// snapshotMagic starts the snapshots of intcode programs, along with their
+// format version.
+const snapshotMagic = "INTC\x01"
+
+// Clone returns a copy of the intcode program, which runs independently of it.
+// Memory beyond the end of the program is shared by both copies until they
+// write to it. The InputFunc and OutputFunc are shared.
+func (intcode *Intcode) Clone() *Intcode {
+	clone := *intcode
+	clone.Inputs = append([]int(nil), intcode.Inputs...)
+	clone.Outputs = append([]int(nil), intcode.Outputs...)
+	if intcode.memory == nil {
+		clone.Program = append([]int(nil), intcode.Program...)
+		return &clone
+	}
+
+	// Copy the pages of the program, so that each copy sees its own changes in
+	// Program.
+	program := make([]int, cap(intcode.Program))
+	copy(program, intcode.Program[:cap(intcode.Program)])
+	clone.Program = program[:len(intcode.Program)]
+	clone.memory = newMemory(program)
+	intcode.memory.share(clone.memory, len(program)/pageSize)
+	clone.cache = append([]cached(nil), intcode.cache...)
+	return &clone
+}
+
+// Snapshot returns the state of the intcode program: its position, relative
+// base, state, inputs, outputs and memory. The InputFunc and OutputFunc are not
+// part of it.
+func (intcode *Intcode) Snapshot() []byte {
+	return intcode.encode(true)
+}
+
+// Restore sets the state of the intcode program to a snapshot made by
+// Snapshot.
+func (intcode *Intcode) Restore(snapshot []byte) error {
+	if len(snapshot) < len(snapshotMagic) || string(snapshot[:len(snapshotMagic)]) != snapshotMagic {
+		return errors.New("invalid snapshot: unknown format")
+	}
+	d := decoder{data: snapshot[len(snapshotMagic):]}
+
+	pos, relativeBase, state := d.int(), d.int(), State(d.int())
+	inputs, outputs := d.ints(), d.ints()
+
+	length := d.int()
+	if length < 0 || length > densePages*pageSize {
+		return errors.New("invalid snapshot: invalid program length")
+	}
+	program := make([]int, (length+pageSize-1)/pageSize*pageSize)
+	memory := newMemory(program)
+	for pages := d.int(); pages > 0 && d.err == nil; pages-- {
+		p := d.int()
+		if p < 0 || p > math.MaxInt>>pageBits {
+			return fmt.Errorf("invalid snapshot: invalid page %d", p)
+		}
+		page := memory.page(p, true)
+		for i := range page {
+			page[i] = d.int()
+		}
+	}
+	if d.err != nil {
+		return fmt.Errorf("invalid snapshot: %w", d.err)
+	}
+	if len(d.data) > 0 {
+		return errors.New("invalid snapshot: trailing data")
+	}
+
+	intcode.Program = program[:length]
+	intcode.Inputs, intcode.Outputs = inputs, outputs
+	intcode.Pos, intcode.RelativeBase, intcode.State = pos, relativeBase, state
+	intcode.memory = memory
+	intcode.cache = make([]cached, length)
+	return nil
+}
+
+// Hash returns a hash of the state of the intcode program. Programs in the same
+// state, which run the same way from there, have the same hash. Outputs are not
+// part of the state.
+func (intcode *Intcode) Hash() uint64 {
+	h := fnv.New64a()
+	h.Write(intcode.encode(false))
+	return h.Sum64()
+}
+
+// encode returns a snapshot of the intcode program, with or without its
+// outputs. Pages of memory that only hold zeros are left out, so that
+// programs in the same state have the same snapshot.
+func (intcode *Intcode) encode(outputs bool) []byte {
+	if intcode.memory == nil {
+		intcode.load()
+	}
+
+	b := []byte(snapshotMagic)
+	b = binary.AppendVarint(b, int64(intcode.Pos))
+	b = binary.AppendVarint(b, int64(intcode.RelativeBase))
+	b = binary.AppendVarint(b, int64(intcode.State))
+	b = appendInts(b, intcode.Inputs)
+	if outputs {
+		b = appendInts(b, intcode.Outputs)
+	} else {
+		b = appendInts(b, nil)
+	}
+
+	b = binary.AppendVarint(b, int64(len(intcode.Program)))
+	var pages []int
+	for _, p := range intcode.memory.indexes() {
+		for _, value := range intcode.memory.page(p, false) {
+			if value != 0 {
+				pages = append(pages, p)
+				break
+			}
+		}
+	}
+	b = binary.AppendVarint(b, int64(len(pages)))
+	for _, p := range pages {
+		b = binary.AppendVarint(b, int64(p))
+		for _, value := range intcode.memory.page(p, false) {
+			b = binary.AppendVarint(b, int64(value))
+		}
+	}
+	return b
+}
+
+// appendInts appends the length and the values of values to b.
+func appendInts(b []byte, values []int) []byte {
+	b = binary.AppendVarint(b, int64(len(values)))
+	for _, value := range values {
+		b = binary.AppendVarint(b, int64(value))
+	}
+	return b
+}
+
+// A decoder reads the values of a snapshot. After an error, it only returns
+// zeros.
+type decoder struct {
+	data []byte
+	err  error
+}
+
+func (d *decoder) int() int {
+	if d.err != nil {
+		return 0
+	}
+	value, n := binary.Varint(d.data)
+	if n <= 0 {
+		d.err = errors.New("truncated data")
+		return 0
+	}
+	d.data = d.data[n:]
+	return int(value)
+}
+
+func (d *decoder) ints() []int {
+	n := d.int()
+	if n < 0 || n > len(d.data) {
+		d.err = errors.New("invalid length")
+		return nil
+	}
+	var values []int
+	for i := 0; i < n; i++ {
+		values = append(values, d.int())
+	}
+	return values
+}
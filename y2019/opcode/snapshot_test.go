@@ -0,0 +1,97 @@
+package opcode
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestClone(t *testing.T) {
+	intcode := &Intcode{Program: append([]int(nil), echo...), Inputs: []int{1, 2}}
+	if err := intcode.SetValue(1e6, 7); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	clone := intcode.Clone()
+	if _, err := clone.RunIntcode(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := clone.SetValue(1e6, 8); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if intcode.Pos != 0 || fmt.Sprint(intcode.Inputs) != "[1 2]" || len(intcode.Outputs) != 0 {
+		t.Errorf("expected original program not to run, got position %d, inputs %v and outputs %v", intcode.Pos, intcode.Inputs, intcode.Outputs)
+	}
+	if intcode.Program[0] != 3 || clone.Program[0] != 1 {
+		t.Errorf("expected programs to start with 3 and 1, got %d and %d", intcode.Program[0], clone.Program[0])
+	}
+	if value, _ := intcode.GetValue(1e6); value != 7 {
+		t.Errorf("expected original memory to hold 7, got %d", value)
+	}
+	if value, _ := clone.GetValue(1e6); value != 8 {
+		t.Errorf("expected cloned memory to hold 8, got %d", value)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	program, err := Assemble(counter)
+	if err != nil {
+		t.Fatalf("could not assemble: %v", err)
+	}
+	intcode := &Intcode{Program: program, Inputs: []int{3}}
+	if err := intcode.SetValue(5000, -42); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 0; i < 4; i++ {
+		if err := intcode.ComputeStep(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	snapshot := intcode.Snapshot()
+	var restored Intcode
+	if err := restored.Restore(snapshot); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, expected := fmt.Sprint(restored.Outputs, restored.Program), fmt.Sprint(intcode.Outputs, intcode.Program); got != expected {
+		t.Errorf("expected restored program to be:\n%s\ngot:\n%s", expected, got)
+	}
+	if restored.Hash() != intcode.Hash() {
+		t.Error("expected restored program to have the same hash")
+	}
+
+	// Both programs run the same way from there.
+	expected, _ := intcode.RunIntcode()
+	outputs, err := restored.RunIntcode()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmt.Sprint(outputs) != fmt.Sprint(expected) {
+		t.Errorf("expected outputs %v, got %v", expected, outputs)
+	}
+
+	for _, invalid := range [][]byte{nil, []byte("INTC\x01"), append(snapshot, 0)} {
+		if err := restored.Restore(invalid); err == nil {
+			t.Errorf("expected error restoring %q", invalid)
+		}
+	}
+}
+
+func TestHash(t *testing.T) {
+	a := &Intcode{Program: append([]int(nil), echo...)}
+	b := &Intcode{Program: append([]int(nil), echo...)}
+
+	// Allocating memory, or outputting values, does not change the state.
+	if err := b.SetValue(1e6, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b.Outputs = []int{1}
+	if a.Hash() != b.Hash() {
+		t.Error("expected programs in the same state to have the same hash")
+	}
+
+	b.Inputs = []int{1}
+	if a.Hash() == b.Hash() {
+		t.Error("expected programs with different inputs to have different hashes")
+	}
+}
@@ -0,0 +1,90 @@
+package opcode
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A Step is an instruction run by an intcode program.
+type Step struct {
+	Instruction Instruction
+	// Relative base when the instruction ran.
+	RelativeBase int
+	// Values of the parameters the instruction read, in order, leaving out
+	// the parameter it wrote to.
+	Reads []int
+	// Whether the instruction wrote Value to the memory cell at Address.
+	Written        bool
+	Address, Value int
+}
+
+// String returns the step in a compact line, eg.
+// "0008: ADD [16], #-1, [16] ; 3 -1 -> [16]=2".
+func (s Step) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%04d: %s", s.Instruction.Address, s.Instruction)
+	if len(s.Reads) == 0 && !s.Written {
+		return b.String()
+	}
+
+	b.WriteString(" ;")
+	for _, value := range s.Reads {
+		fmt.Fprintf(&b, " %d", value)
+	}
+	if s.Written {
+		fmt.Fprintf(&b, " -> [%d]=%d", s.Address, s.Value)
+	}
+	return b.String()
+}
+
+// A TraceFunc is called with each instruction an intcode program runs, after
+// it ran. Instructions that wait for an input, or that fault, are not traced.
+type TraceFunc func(Step)
+
+// TraceTo returns a TraceFunc that writes steps to w, one per line. Errors
+// writing to w are ignored.
+func TraceTo(w io.Writer) TraceFunc {
+	return func(s Step) {
+		fmt.Fprintln(w, s)
+	}
+}
+
+// traceStep runs the instruction at the current position, and traces it.
+func (intcode *Intcode) traceStep() error {
+	pos := intcode.Pos
+	in, err := intcode.decode(pos)
+	if err != nil {
+		return intcode.compute()
+	}
+
+	step := Step{
+		Instruction:  Instruction{Address: pos, Opcode: in.op},
+		RelativeBase: intcode.RelativeBase,
+	}
+	write := opcodes[in.op].write
+	for j := 0; j < in.op.Params(); j++ {
+		step.Instruction.Params = append(step.Instruction.Params, Param{Mode: in.modes[j], Value: in.params[j]})
+		if j != write {
+			// Values that cannot be read make the instruction fault below.
+			value, _ := intcode.param(&in, j)
+			step.Reads = append(step.Reads, value)
+		}
+	}
+	if write >= 0 {
+		step.Written, step.Address = true, intcode.address(&in, write)
+	}
+
+	if err := intcode.compute(); err != nil {
+		return err
+	}
+	if intcode.State == StateAwaitingInput {
+		return nil
+	}
+
+	if step.Written {
+		step.Value, _ = intcode.GetValue(step.Address)
+	}
+	intcode.Trace(step)
+	return nil
+}
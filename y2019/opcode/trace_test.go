@@ -0,0 +1,76 @@
+package opcode
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestTrace(t *testing.T) {
+	program, err := Assemble(counter)
+	if err != nil {
+		t.Fatalf("could not assemble: %v", err)
+	}
+
+	var b bytes.Buffer
+	intcode := Intcode{Program: program, Trace: TraceTo(&b)}
+	// Waiting for an input is not traced.
+	if _, err := intcode.RunIntcode(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	intcode.Inputs = []int{2}
+	if _, err := intcode.RunIntcode(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"0000: IN [12] ; -> [12]=2",
+		"0002: OUT [12] ; 2",
+		"0004: ADD [12], #-1, [12] ; 2 -1 -> [12]=1",
+		"0008: JNZ [12], #2 ; 1 2",
+		"0002: OUT [12] ; 1",
+		"0004: ADD [12], #-1, [12] ; 1 -1 -> [12]=0",
+		"0008: JNZ [12], #2 ; 0 2",
+		"0011: HLT",
+	}
+	if trace := strings.TrimSpace(b.String()); trace != strings.Join(expected, "\n") {
+		t.Errorf("expected trace:\n%s\ngot:\n%s", strings.Join(expected, "\n"), trace)
+	}
+}
+
+func TestProfiler(t *testing.T) {
+	program, err := Assemble(counter)
+	if err != nil {
+		t.Fatalf("could not assemble: %v", err)
+	}
+
+	profiler := NewProfiler()
+	intcode := Intcode{Program: program, Inputs: []int{3}, Trace: profiler.Trace}
+	if _, err := intcode.RunIntcode(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if profiler.Steps != 11 {
+		t.Errorf("expected 11 steps, got %d", profiler.Steps)
+	}
+	if hotspots := profiler.Hotspots(2); fmt.Sprint(hotspots) != "[2 4]" {
+		t.Errorf("expected hotspots [2 4], got %v", hotspots)
+	}
+	if profiler.Opcodes[OpAdd] != 3 || profiler.Opcodes[OpHalt] != 1 {
+		t.Errorf("expected 3 ADD and 1 HLT, got %v", profiler.Opcodes)
+	}
+
+	var b bytes.Buffer
+	if err := profiler.WriteReport(&b, program); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, line := range []string{
+		"    3  27.3%  0004: ADD [12], #-1, [12]",
+		"              0012: DATA 0",
+	} {
+		if !strings.Contains(b.String(), line+"\n") {
+			t.Errorf("expected report to contain %q, got:\n%s", line, b.String())
+		}
+	}
+}
@@ -66,7 +66,7 @@ func PartTwo(input io.Reader, answer io.Writer) error {
 
 // TYPES
 
-type Pile []rune
+type Pile = helpers.Stack[rune]
 
 var errorScoreMap = map[rune]int{
 	')': 3,
@@ -97,24 +97,21 @@ func parseLines(lines []string) (instructions [][]rune) {
 }
 
 // Find the illegal character
-func findIllegalCharacter(instruction []rune) (illegalChar rune, isIllegal bool, remainder Pile) {
+func findIllegalCharacter(instruction []rune) (illegalChar rune, isIllegal bool, remainder *Pile) {
 
-	var p Pile
+	p := &Pile{}
 
 	for _, char := range instruction {
 
 		if char == '(' || char == '{' || char == '[' || char == '<' {
-			p = p.push(char)
+			p.Push(char)
 		} else {
 
-			if len(p) == 0 {
+			previous, ok := p.Pop()
+			if !ok {
 				return illegalChar, false, p
 			}
 
-			var previous rune
-
-			previous, p = p.pop()
-
 			switch char {
 			case ')':
 				if previous != '(' {
@@ -139,25 +136,10 @@ func findIllegalCharacter(instruction []rune) (illegalChar rune, isIllegal bool,
 	return illegalChar, false, p
 }
 
-// Push on the pile
-func (p Pile) push(char rune) Pile {
-	p = append(p, char)
-
-	return p
-}
-
-// Pop from the pile
-func (p Pile) pop() (rune, Pile) {
-	char := p[len(p)-1]
-
-	return char, p[:len(p)-1]
-}
-
 // Complete an instruction and compute the autocomplete score
-func completeInstruction(remainder Pile) (score int) {
-	var char rune
-	for len(remainder) > 0 {
-		char, remainder = remainder.pop()
+func completeInstruction(remainder *Pile) (score int) {
+	for remainder.Len() > 0 {
+		char, _ := remainder.Pop()
 		score *= 5
 		score += completeScoreMap[char]
 	}
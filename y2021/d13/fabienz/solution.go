@@ -52,10 +52,13 @@ func PartTwo(input io.Reader, answer io.Writer) error {
 		paper.fold(fold)
 	}
 
-	// Human have to read the answer in the debug output
-	paper.display()
+	// Read the letters drawn by the dots.
+	letters, err := helpers.OCR(paper.dots())
+	if err != nil {
+		return fmt.Errorf("could not read paper: %w", err)
+	}
 
-	_, err = fmt.Fprintf(answer, "%s", "AHPRPAUZ")
+	_, err = fmt.Fprintf(answer, "%s", letters)
 	if err != nil {
 		return fmt.Errorf("could not write answer: %w", err)
 	}
@@ -143,19 +146,15 @@ func (p *Paper) fold(instruction Fold) {
 
 }
 
-// Display a paper
-func (p *Paper) display() {
+// Returns the dots of a paper
+func (p *Paper) dots() *helpers.Grid[bool] {
+	dots := helpers.NewGrid[bool](p.cols, p.rows)
 	for row := 0; row < p.rows; row++ {
-		toPrint := ""
 		for col := 0; col < p.cols; col++ {
-			if p.grid[row][col] == "#" {
-				toPrint += p.grid[row][col]
-			} else {
-				toPrint += "."
-			}
+			dots.Set(helpers.Coord2D{X: col, Y: row}, p.grid[row][col] == "#")
 		}
-		helpers.Println(toPrint)
 	}
+	return dots
 }
 
 // Count the dots in a paper
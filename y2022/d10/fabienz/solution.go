@@ -63,8 +63,13 @@ func PartTwo(input io.Reader, answer io.Writer) error {
 	// Run the program.
 	program.run()
 
-	// The result (PAPKFKEJ) is written in the console.
-	_, err = fmt.Fprintf(answer, "%s", "PAPKFKEJ")
+	// Read the letters drawn on the screen.
+	letters, err := helpers.OCR(program.screen)
+	if err != nil {
+		return fmt.Errorf("could not read screen: %w", err)
+	}
+
+	_, err = fmt.Fprintf(answer, "%s", letters)
 	if err != nil {
 		return fmt.Errorf("could not write answer: %w", err)
 	}
@@ -72,6 +77,12 @@ func PartTwo(input io.Reader, answer io.Writer) error {
 	return nil
 }
 
+// Size of the CRT screen.
+const (
+	screenWidth  = 40
+	screenHeight = 6
+)
+
 // Type representing an instruction.
 type instruction struct {
 	operation string
@@ -84,6 +95,7 @@ type program struct {
 	cycle        int
 	savedXStates map[int]int // Used to keep track of the x state at each cycle.
 	shouldDraw   bool        // Used to draw the signal.
+	screen       *helpers.Grid[bool]
 }
 
 // Regex to match an instruction.
@@ -129,6 +141,7 @@ func initProgram(lines []string, registers map[string]int, shouldDraw bool) (pro
 		cycle:        0,
 		savedXStates: savedXStates,
 		shouldDraw:   shouldDraw,
+		screen:       helpers.NewGrid[bool](screenWidth, screenHeight),
 	}, nil
 }
 
@@ -137,16 +150,16 @@ func (p *program) apply(instruction instruction) {
 	switch instruction.operation {
 	case "noop":
 		if p.shouldDraw {
-			p.setPixel(p.cycle%40, p.registers["x"])
+			p.setPixel(p.cycle, p.registers["x"])
 		}
 		p.cycle++
 		p.savedXStates[p.cycle] = p.registers["x"]
 	case "addx":
 		if p.shouldDraw {
-			p.setPixel(p.cycle%40, p.registers["x"])
+			p.setPixel(p.cycle, p.registers["x"])
 		}
 		if p.shouldDraw {
-			p.setPixel((p.cycle+1)%40, p.registers["x"])
+			p.setPixel(p.cycle+1, p.registers["x"])
 		}
 		// addx takes two cycles to execute.
 		p.savedXStates[p.cycle+1] = p.registers["x"]
@@ -168,15 +181,11 @@ func (p *program) signalStrength(cycle int) int {
 	return p.savedXStates[cycle] * cycle
 }
 
-// Draw a pixel at a given position.
-func (p *program) setPixel(x int, spritePos int) {
-	pixel := "."
-	if helpers.AbsInt(spritePos-x) <= 1 {
-		pixel = "#"
-	}
-
-	fmt.Printf("%s", pixel)
-	if x%40 == 39 {
-		fmt.Printf("\n")
+// Draw the pixel of the screen at a given cycle.
+func (p *program) setPixel(cycle int, spritePos int) {
+	x, y := cycle%screenWidth, cycle/screenWidth
+	if y >= screenHeight {
+		return
 	}
+	p.screen.Set(helpers.Coord2D{X: x, Y: y}, helpers.AbsInt(spritePos-x) <= 1)
 }
@@ -28,15 +28,16 @@ func ExamplePartTwo() {
 	}
 	defer file.Close()
 
-	if err := PartTwo(file, os.Stdout); err != nil {
-		log.Fatalf("could not solve: %v", err)
-	}
+	// The screen shows:
 	// ###...##..###..#..#.####.#..#.####...##.
 	// #..#.#..#.#..#.#.#..#....#.#..#.......#.
 	// #..#.#..#.#..#.##...###..##...###.....#.
 	// ###..####.###..#.#..#....#.#..#.......#.
 	// #....#..#.#....#.#..#....#.#..#....#..#.
 	// #....#..#.#....#..#.#....#..#.####..##..
+	if err := PartTwo(file, os.Stdout); err != nil {
+		log.Fatalf("could not solve: %v", err)
+	}
 	// Output: PAPKFKEJ
 }
 
@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"github.com/fabienzucchet/adventofcode/helpers"
+	"github.com/fabienzucchet/adventofcode/helpers/search"
 )
 
 // PartOne solves the first problem of day 12 of Advent of Code 2022.
@@ -19,9 +20,16 @@ func PartOne(input io.Reader, answer io.Writer) error {
 	m := parseMap(lines)
 
 	// Find the shortest path
-	dist := m.findShortestPath(false)
+	path := search.BFS(m.start, func(c Coord) []Coord {
+		return m.neighbors(c, false)
+	}, func(c Coord) bool {
+		return c == m.end
+	})
+	if !path.Found {
+		return fmt.Errorf("could not reach the end")
+	}
 
-	_, err = fmt.Fprintf(answer, "%d", dist[m.end])
+	_, err = fmt.Fprintf(answer, "%d", path.Cost())
 	if err != nil {
 		return fmt.Errorf("could not write answer: %w", err)
 	}
@@ -43,18 +51,17 @@ func PartTwo(input io.Reader, answer io.Writer) error {
 	// Reverse end and start
 	m.start, m.end = m.end, m.start
 
-	// Find the shortest path
-	dist := m.findShortestPath(true)
-
-	// Find the minimum distance to the end
-	minDist := 100000
-	for c, d := range dist {
-		if d < minDist && m.elevation[c] == 0 {
-			minDist = d
-		}
+	// Find the shortest path to the closest lowest square
+	path := search.BFS(m.start, func(c Coord) []Coord {
+		return m.neighbors(c, true)
+	}, func(c Coord) bool {
+		return m.elevation[c] == 0
+	})
+	if !path.Found {
+		return fmt.Errorf("could not reach the lowest elevation")
 	}
 
-	_, err = fmt.Fprintf(answer, "%d", minDist)
+	_, err = fmt.Fprintf(answer, "%d", path.Cost())
 	if err != nil {
 		return fmt.Errorf("could not write answer: %w", err)
 	}
@@ -101,43 +108,6 @@ func parseMap(lines []string) Map {
 	return m
 }
 
-// Find the shortest path using Dijkstra's algorithm
-func (m *Map) findShortestPath(reverse bool) map[Coord]int {
-	dist := make(map[Coord]int)
-	for c := range m.elevation {
-		dist[c] = 10000
-	}
-	dist[m.start] = 0
-	visited := make(map[Coord]bool)
-
-	for len(visited) < len(m.elevation) {
-		// Find the closest unvisited node
-		var closest Coord
-		minDist := 100000
-		for c, d := range dist {
-			if d < minDist && !visited[c] {
-				minDist = d
-				closest = c
-			}
-		}
-
-		// Mark it as visited
-		visited[closest] = true
-
-		// Update the distance of its neighbors
-		for _, n := range m.neighbors(closest, reverse) {
-			if !visited[n] {
-				d := dist[closest] + 1
-				if d < dist[n] {
-					dist[n] = d
-				}
-			}
-		}
-	}
-
-	return dist
-}
-
 func (m *Map) neighbors(c Coord, reverse bool) []Coord {
 	var neighbors []Coord
 
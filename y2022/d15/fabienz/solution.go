@@ -6,6 +6,7 @@ import (
 	"math"
 
 	"github.com/fabienzucchet/adventofcode/helpers"
+	"github.com/fabienzucchet/adventofcode/helpers/parse"
 )
 
 // const SEACHROW = 10
@@ -73,26 +74,30 @@ type beacon struct {
 	pos helpers.Coord2D
 }
 
+// A report of a sensor, as written in the input.
+type report struct {
+	SensorX, SensorY int
+	BeaconX, BeaconY int
+}
+
 // Parse the input.
 func parseLines(lines []string) ([]sensor, []beacon, error) {
-	sensors := make([]sensor, len(lines))
-	beacons := make([]beacon, 0)
-
-	for i, line := range lines {
-		var sensX, sensY, beaconX, beaconY int
+	reports, err := parse.ScanLines[report](lines, "Sensor at x={sensorX}, y={sensorY}: closest beacon is at x={beaconX}, y={beaconY}")
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not parse input: %w", err)
+	}
 
-		_, err := fmt.Sscanf(line, "Sensor at x=%d, y=%d: closest beacon is at x=%d, y=%d", &sensX, &sensY, &beaconX, &beaconY)
-		if err != nil {
-			return nil, nil, fmt.Errorf("could not parse line %q: %w", line, err)
-		}
+	sensors := make([]sensor, len(reports))
+	beacons := make([]beacon, 0)
 
+	for i, r := range reports {
 		sensorPos := helpers.Coord2D{
-			X: sensX,
-			Y: sensY,
+			X: r.SensorX,
+			Y: r.SensorY,
 		}
 		beaconPos := helpers.Coord2D{
-			X: beaconX,
-			Y: beaconY,
+			X: r.BeaconX,
+			Y: r.BeaconY,
 		}
 		closestBeaconDistance := sensorPos.ManhattanDistance(beaconPos)
 